@@ -1,5 +1,5 @@
 module github.com/romycode/goselector
 
-go 1.17
+go 1.18
 
 require golang.org/x/net v0.0.0-20210903162142-ad29c8ab022f
@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"golang.org/x/net/html"
+
+	"github.com/romycode/goselector/pkg/selector"
 )
 
 func main() {
@@ -36,25 +38,26 @@ func main() {
 		log.Fatal(err)
 	}
 
-	iterateNodes(doc)
-}
+	sel, err := selector.Compile("*")
+	if err != nil {
+		log.Fatal(err)
+	}
 
-func iterateNodes(n *html.Node) {
-	for e := n.FirstChild; e != nil; e = e.NextSibling {
-		if e.Type == html.ElementNode {
-			fmt.Println("# <==============================> #")
-			fmt.Printf("TAG: %v \n", e.Data)
-			fmt.Printf("ATTRIBUTES: %#v \n", func() map[string]string {
-				attr := map[string]string{}
-				for _, attribute := range e.Attr {
-					attr[attribute.Key] = attribute.Val
-				}
-				return attr
-			}())
-			fmt.Println("# <==============================> #")
-		}
+	iterateNodes(selector.QueryAll(doc, sel))
+}
 
-		iterateNodes(e)
+func iterateNodes(nodes []*html.Node) {
+	for _, e := range nodes {
+		fmt.Println("# <==============================> #")
+		fmt.Printf("TAG: %v \n", e.Data)
+		fmt.Printf("ATTRIBUTES: %#v \n", func() map[string]string {
+			attr := map[string]string{}
+			for _, attribute := range e.Attr {
+				attr[attribute.Key] = attribute.Val
+			}
+			return attr
+		}())
+		fmt.Println("# <==============================> #")
 	}
 
 	//if n.Type == html.ElementNode {
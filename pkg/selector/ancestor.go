@@ -0,0 +1,140 @@
+package selector
+
+import (
+	"golang.org/x/net/html"
+)
+
+// lazyMatch holds a match result computed the first time it is needed
+type lazyMatch uint8
+
+const (
+	unknownMatch lazyMatch = iota
+	notMatched
+	isMatched
+)
+
+func toLazyMatch(matched bool) lazyMatch {
+	if matched {
+		return isMatched
+	}
+
+	return notMatched
+}
+
+// ancestorMatches holds for the ancestors of the element visited by walk if they match the left side of the ' ' and
+// '>' combinators, computed once for every ancestor when a descendant needs it, so chains like 'div .a span' read
+// the results of the ancestors instead of walking the ancestors again for every span
+type ancestorMatches struct {
+	index     map[*CombinatorSelector]int // position in lefts of the left side of the combinators
+	lefts     []Sel
+	ancestors []*html.Node // ancestors of the visited element from the outermost one
+	matched   []lazyMatch  // matched[d*len(lefts)+i] tells if ancestors[d] matches lefts[i]
+	found     []lazyMatch  // found[d*len(lefts)+i] tells if ancestors[d] or an outer ancestor matches lefts[i]
+}
+
+// newAncestorMatches prepares the ancestors of the descendants of root to match the combinators of sels,
+// nil when none of them has ' ' or '>' combinators
+func newAncestorMatches(root *html.Node, sels []Sel) *ancestorMatches {
+	a := &ancestorMatches{
+		index: map[*CombinatorSelector]int{},
+	}
+	for _, sel := range sels {
+		for c, ok := sel.(*CombinatorSelector); ok; c, ok = c.left.(*CombinatorSelector) {
+			if c.combinator == ' ' || c.combinator == '>' {
+				a.index[c] = len(a.lefts)
+				a.lefts = append(a.lefts, c.left)
+			}
+		}
+	}
+	if len(a.lefts) == 0 {
+		return nil
+	}
+
+	var outer []*html.Node // root and its ancestors are the outermost ancestors of the descendants
+	for p := root; p != nil && html.ElementNode == p.Type; p = p.Parent {
+		outer = append(outer, p)
+	}
+	for i := len(outer) - 1; i >= 0; i-- {
+		a.push(outer[i])
+	}
+
+	return a
+}
+
+// push adds n as the innermost ancestor, its results are unknown until a descendant needs them
+func (a *ancestorMatches) push(n *html.Node) {
+	a.ancestors = append(a.ancestors, n)
+	for range a.lefts {
+		a.matched = append(a.matched, unknownMatch)
+		a.found = append(a.found, unknownMatch)
+	}
+}
+
+// pop removes the innermost ancestor
+func (a *ancestorMatches) pop() {
+	a.ancestors = a.ancestors[:len(a.ancestors)-1]
+	a.matched = a.matched[:len(a.ancestors)*len(a.lefts)]
+	a.found = a.found[:len(a.ancestors)*len(a.lefts)]
+}
+
+// matchVisited checks if s matches n, the element visited after the last pushed ancestor
+func (a *ancestorMatches) matchVisited(s Sel, n *html.Node) bool {
+	return a.match(s, n, len(a.ancestors))
+}
+
+// match checks if s matches n, whose ancestors are the first depth ones, reading the results of the ancestors for
+// the ' ' and '>' combinators, the siblings of n share its ancestors
+func (a *ancestorMatches) match(s Sel, n *html.Node, depth int) bool {
+	c, ok := s.(*CombinatorSelector)
+	if !ok {
+		return s.Match(n)
+	}
+	if html.ElementNode != n.Type || !c.right.Match(n) {
+		return false
+	}
+
+	switch c.combinator {
+	case '>':
+		return depth > 0 && a.matchedAt(depth-1, a.index[c])
+	case ' ':
+		return depth > 0 && a.foundAt(depth-1, a.index[c])
+	case '+':
+		p := previousElementSibling(n)
+		return p != nil && a.match(c.left, p, depth)
+	case '~':
+		for p := previousElementSibling(n); p != nil; p = previousElementSibling(p) {
+			if a.match(c.left, p, depth) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// matchedAt checks if the ancestor at depth matches lefts[left]
+func (a *ancestorMatches) matchedAt(depth, left int) bool {
+	i := depth*len(a.lefts) + left
+	if a.matched[i] == unknownMatch {
+		a.matched[i] = toLazyMatch(a.match(a.lefts[left], a.ancestors[depth], depth))
+	}
+
+	return a.matched[i] == isMatched
+}
+
+// foundAt checks if the ancestor at depth or an outer one matches lefts[left], filling the results from the closest
+// outer ancestor whose result is known, so every ancestor is matched at most once
+func (a *ancestorMatches) foundAt(depth, left int) bool {
+	d := depth
+	for d >= 0 && a.found[d*len(a.lefts)+left] == unknownMatch {
+		d--
+	}
+
+	found := d >= 0 && a.found[d*len(a.lefts)+left] == isMatched
+	for d++; d <= depth; d++ {
+		found = found || a.matchedAt(d, left)
+		a.found[d*len(a.lefts)+left] = toLazyMatch(found)
+	}
+
+	return found
+}
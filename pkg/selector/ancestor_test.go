@@ -0,0 +1,101 @@
+package selector
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestNewAncestorMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     []string
+	}{
+		{name: "nothing for compound selector", selector: "div.a", want: nil},
+		{name: "nothing for sibling combinators", selector: "td.label + td ~ td", want: nil},
+		{name: "left side of descendant combinator", selector: "div.a span", want: []string{"div.a"}},
+		{name: "left side of every combinator", selector: "#main > ul li", want: []string{"#main > ul", "#main"}},
+		{name: "left side of the ancestors of a sibling", selector: "tr > td.label + td", want: []string{"tr"}},
+		{name: "left side of every selector of the list", selector: "ul > li, p a", want: []string{"ul", "p"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			s, err := Compile(tt.selector)
+			if err != nil {
+				t1.Errorf("Compile() error = %v", err)
+				return
+			}
+
+			var got []string
+			if a := newAncestorMatches(nil, newMatcher(nil, s).sels); a != nil {
+				for _, left := range a.lefts {
+					got = append(got, left.String())
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("newAncestorMatches() lefts = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAncestorMatches_Root(t *testing.T) {
+	root, _ := html.Parse(strings.NewReader(queryDoc))
+	list := findElement(root, "list")
+	tests := []struct {
+		name     string
+		selector string
+		want     []string
+	}{
+		{name: "match ancestors of the root", selector: "body li", want: []string{"first", "second"}},
+		{name: "match the root as parent", selector: "ul > li", want: []string{"first", "second"}},
+		{name: "match the siblings of the root", selector: "h2 ~ ul li, h2 li", want: []string{"first", "second"}},
+		{name: "not match outside of the ancestors", selector: "h1 li", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			s, err := Compile(tt.selector)
+			if err != nil {
+				t1.Errorf("Compile() error = %v", err)
+				return
+			}
+			if got := ids(QueryAll(list, s)); !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("QueryAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// matchedNodes is a selector recording the elements it is matched against
+type matchedNodes struct {
+	Sel
+	nodes []*html.Node
+}
+
+func (m *matchedNodes) Match(n *html.Node) bool {
+	m.nodes = append(m.nodes, n)
+	return m.Sel.Match(n)
+}
+
+func TestAncestorMatches_MatchOnce(t *testing.T) {
+	root, _ := html.Parse(strings.NewReader(deepDoc(20)))
+	s, _ := Compile(".a span")
+	left := &matchedNodes{Sel: s.(*CombinatorSelector).left}
+	s.(*CombinatorSelector).left = left
+
+	if got := len(QueryAll(root, s)); got != 1000 {
+		t.Errorf("QueryAll() found %d elements, want 1000", got)
+	}
+
+	seen := map[*html.Node]bool{}
+	for _, n := range left.nodes {
+		if seen[n] {
+			t.Errorf("Match() called again for <%s %v>", n.Data, n.Attr)
+			return
+		}
+		seen[n] = true
+	}
+}
@@ -1,71 +1,148 @@
 package selector
 
 import (
-	"fmt"
 	"strings"
 
 	"golang.org/x/net/html"
 )
 
 type AttrSelector struct {
-	key string
-	op  string
-	val string
+	key      string
+	op       string
+	val      string
+	modifier byte
+	ns       namespaceComponent
 }
 
 func (t AttrSelector) Match(n *html.Node) bool {
-	if html.ElementNode == n.Type {
-		for _, attr := range n.Attr {
-			switch t.op {
-			case "=":
-				if t.key == attr.Key && t.val == attr.Val {
-					return true
-				}
-				return false
-			case "~=", "*=":
-				if t.key == attr.Key && strings.Contains(attr.Val, t.val) {
-					return true
-				}
-				return false
-			case "|=":
-				if t.key == attr.Key {
-					if attr.Val == t.val || len(attr.Val) >= len(t.val) && attr.Val[:len(t.val)+1] == t.val+"-" {
-						return true
-					}
-				}
-				return false
-			case "^=":
-				if t.key == attr.Key {
-					if attr.Val[:len(t.val)] == t.val {
-						return true
-					}
-				}
-				return false
-			case "$=":
-				if t.key == attr.Key {
-					if attr.Val[len(attr.Val)-len(t.val):] == t.val {
-						return true
-					}
-				}
-				return false
-			default:
-				if t.key == attr.Key {
-					return true
-				}
+	if html.ElementNode != n.Type {
+		return false
+	}
+
+	key := t.keyFor(n)
+	for _, attr := range n.Attr {
+		if key != attr.Key || !t.ns.matchesAttribute(attr.Namespace) {
+			continue
+		}
+
+		val, sel := attr.Val, t
+		if t.isCaseInsensitive(n) {
+			val, sel = toASCIILower(attr.Val), AttrSelector{op: t.op, val: toASCIILower(t.val)}
+		}
+		if sel.matchValue(val) { // with '*|' the attribute can be in several namespaces, any of them can match
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t AttrSelector) Specificity() [3]int {
+	return [3]int{0, 1, 0}
+}
+
+func (t AttrSelector) String() string {
+	var str strings.Builder
+	str.WriteString("[" + t.ns.String() + serializeIdentifier(t.key))
+	if t.op != "" {
+		str.WriteString(t.op + serializeString(t.val))
+	}
+	if t.modifier != 0 {
+		str.WriteString(" " + string(t.modifier))
+	}
+	str.WriteString("]")
+
+	return str.String()
+}
+
+// keyFor returns the attribute name to look for in n, the names of the attributes of html elements are matched
+// ignoring ASCII case https://html.spec.whatwg.org/#case-sensitivity-of-selectors
+func (t AttrSelector) keyFor(n *html.Node) string {
+	if "" == n.Namespace {
+		return toASCIILower(t.key)
+	}
+
+	return t.key
+}
+
+// isCaseInsensitive checks if the value must be compared ignoring ASCII case, by the selector modifier or
+// because is one of the attributes defined as case-insensitive for html elements https://html.spec.whatwg.org/#case-sensitivity-of-selectors
+func (t AttrSelector) isCaseInsensitive(n *html.Node) bool {
+	switch t.modifier {
+	case 'i':
+		return true
+	case 's':
+		return false
+	}
+
+	return "" == n.Namespace && caseInsensitiveAttributes[toASCIILower(t.key)]
+}
+
+// matchValue checks if the attribute value satisfies the selector operation
+// as defined in https://drafts.csswg.org/selectors-4/#attribute-representation and https://drafts.csswg.org/selectors-4/#attribute-substrings
+func (t AttrSelector) matchValue(val string) bool {
+	switch t.op {
+	case "":
+		return true
+	case "=":
+		return val == t.val
+	case "~=": // an empty value or one with whitespace can never be a word of the list
+		if t.val == "" || strings.IndexFunc(t.val, isASCIIWhitespace) != -1 {
+			return false
+		}
+		for _, word := range strings.FieldsFunc(val, isASCIIWhitespace) {
+			if word == t.val {
+				return true
 			}
 		}
+		return false
+	case "|=":
+		return val == t.val || strings.HasPrefix(val, t.val+"-")
+	case "^=": // substring operations with an empty value never match
+		return t.val != "" && strings.HasPrefix(val, t.val)
+	case "$=":
+		return t.val != "" && strings.HasSuffix(val, t.val)
+	case "*=":
+		return t.val != "" && strings.Contains(val, t.val)
 	}
 
 	return false
 }
 
+var caseInsensitiveAttributes = map[string]bool{
+	"accept": true, "accept-charset": true, "align": true, "alink": true, "axis": true, "bgcolor": true,
+	"charset": true, "checked": true, "clear": true, "codetype": true, "color": true, "compact": true,
+	"declare": true, "defer": true, "dir": true, "direction": true, "disabled": true, "enctype": true,
+	"face": true, "frame": true, "hreflang": true, "http-equiv": true, "lang": true, "language": true,
+	"link": true, "media": true, "method": true, "multiple": true, "nohref": true, "noresize": true,
+	"noshade": true, "nowrap": true, "readonly": true, "rel": true, "rev": true, "rules": true,
+	"scope": true, "scrolling": true, "selected": true, "shape": true, "target": true, "text": true,
+	"type": true, "valign": true, "valuetype": true, "vlink": true,
+}
+
+// toASCIILower converts ASCII upper case letters to lower case leaving any other character untouched
+func toASCIILower(s string) string {
+	if strings.IndexFunc(s, func(r rune) bool { return 'A' <= r && r <= 'Z' }) == -1 {
+		return s // nothing to convert, avoid copying
+	}
+
+	b := []byte(s)
+	for i, char := range b {
+		if 'A' <= char && char <= 'Z' {
+			b[i] = char + 'a' - 'A'
+		}
+	}
+
+	return string(b)
+}
+
 type AttributeParser struct {
-	SelParser
+	*SelParser
 }
 
 func NewAttributeParser(sel string) *AttributeParser {
 	return &AttributeParser{
-		SelParser{
+		&SelParser{
 			sel:    sel,
 			selLen: len(sel),
 		},
@@ -73,57 +150,104 @@ func NewAttributeParser(sel string) *AttributeParser {
 }
 
 func (a AttributeParser) Parse() (Sel, error) {
-	if a.sel[a.pos] != '[' {
-		return nil, fmt.Errorf("expected attribute selector ([attr=val]), found '%s'", a.sel)
+	if a.pos >= a.selLen || a.sel[a.pos] != '[' {
+		return nil, a.newError("attribute selector ([attr=val])")
+	}
+	a.pos++
+	a.skipWhitespace()
+
+	ns, err := a.parseNamespace(false)
+	if err != nil {
+		return nil, err
+	}
+	key, err := a.parseName()
+	if err != nil {
+		return nil, err
+	}
+	if key == "" {
+		return nil, a.newError("attribute name")
+	}
+	a.skipWhitespace()
+
+	sel := &AttrSelector{
+		key: key,
+		ns:  ns,
 	}
-	if !strings.ContainsRune(a.sel, ']') {
-		return nil, fmt.Errorf("expected attribute selector ([attr=val]), found '%s'", a.sel)
+	if a.pos < a.selLen && a.sel[a.pos] != ']' {
+		if sel.op, err = a.parseOperation(); err != nil {
+			return nil, err
+		}
+		a.skipWhitespace()
+
+		if sel.val, err = a.parseValue(); err != nil {
+			return nil, err
+		}
+		a.skipWhitespace()
+
+		if sel.modifier, err = a.parseModifier(); err != nil {
+			return nil, err
+		}
+	}
+
+	if a.pos >= a.selLen || a.sel[a.pos] != ']' {
+		return nil, a.newError("end of attribute selector (])")
 	}
 	a.pos++
 
-	key := ""
-	op := ""
-	val := ""
-	for a.pos < a.selLen {
-		char := a.sel[a.pos]
-
-		switch {
-		case a.isValidTagNameChar(char): // get current "i" if is a valid name character
-			if op == "" {
-				key += string(char)
-			} else {
-				val += string(char)
-			}
-			a.pos++
-			break
-		case char == '=':
-			op = string(char)
-			a.pos++
-			break
-		case char == '\\': // sel have an escaped element https://drafts.csswg.org/css-syntax-3/#escaping
-			c, err := a.parseEscape()
-			if err != nil {
-				return nil, err
-			}
-			key += c
-			break
-		case char == '~' || char == '|' || char == '^' || char == '$' || char == '*':
-			if a.sel[a.pos+1] != '=' {
-				return nil, fmt.Errorf("expected operation for attribute selector ([~,|,^,$,*]=), found '%s'",
-					a.sel[a.pos:a.pos+2])
-			}
-			op = a.sel[a.pos : a.pos+2]
-			a.pos += 2
-			break
-		case char == ' ' || char == '\n' || char == '\r' || char == '\t' || char == ']' || char == '"':
-			a.pos++
-			break
+	return sel, nil
+}
+
+// parseName parses the attribute name and also unquoted values
+func (a AttributeParser) parseName() (string, error) {
+	return a.parseIdentifier()
+}
+
+// parseOperation parses the attribute operation (=, ~=, |=, ^=, $= or *=)
+func (a AttributeParser) parseOperation() (string, error) {
+	char := a.sel[a.pos]
+	switch {
+	case char == '=':
+		a.pos++
+		return string(char), nil
+	case char == '~' || char == '|' || char == '^' || char == '$' || char == '*':
+		a.pos++
+		if a.pos >= a.selLen || a.sel[a.pos] != '=' {
+			return "", a.newError("operation for attribute selector (=)")
 		}
+		a.pos++
+		return a.sel[a.pos-2 : a.pos], nil
 	}
 
-	return &AttrSelector{
-		key: key,
-		op:  op,
-		val: val,
-	}, nil
+	return "", a.newError("operation for attribute selector (=, ~=, |=, ^=, $= or *=)", "end of attribute selector (])")
+}
+
+// parseValue parses the attribute value, quoted or as an identifier
+func (a AttributeParser) parseValue() (string, error) {
+	if a.pos < a.selLen && (a.sel[a.pos] == '"' || a.sel[a.pos] == '\'') {
+		return a.parseString()
+	}
+
+	val, err := a.parseName()
+	if err == nil && val == "" {
+		return "", a.newError("attribute value (identifier or string)")
+	}
+
+	return val, err
+}
+
+// parseModifier parses the case-sensitivity modifier (i or s)
+// as defined in https://drafts.csswg.org/selectors-4/#attribute-case
+func (a AttributeParser) parseModifier() (byte, error) {
+	if a.pos >= a.selLen || a.sel[a.pos] == ']' {
+		return 0, nil
+	}
+
+	switch char := a.sel[a.pos]; char {
+	case 'i', 'I', 's', 'S':
+		a.pos++
+		a.skipWhitespace()
+		return char | 0x20, nil // lower case
+	default:
+		return 0, a.newError("attribute modifier (i or s)", "end of attribute selector (])")
+	}
 }
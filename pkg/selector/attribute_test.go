@@ -87,6 +87,81 @@ func TestAttributeParser_Parse(t *testing.T) {
 				val: "val",
 			},
 		},
+		{
+			name:     "parse an attribute sel with case-insensitive modifier",
+			selector: `[key="val" i]`,
+			want: &AttrSelector{
+				key:      "key",
+				op:       "=",
+				val:      "val",
+				modifier: 'i',
+			},
+		},
+		{
+			name:     "parse an attribute sel with upper case case-sensitive modifier",
+			selector: `[ key ^= val S ]`,
+			want: &AttrSelector{
+				key:      "key",
+				op:       "^=",
+				val:      "val",
+				modifier: 's',
+			},
+		},
+		{
+			name:     "parse an attribute sel with spaces in quoted value",
+			selector: `[title="Hello world"]`,
+			want:     &AttrSelector{key: "title", op: "=", val: "Hello world"},
+		},
+		{
+			name:     "parse an attribute sel with single quoted value",
+			selector: `[data-x='a.b']`,
+			want:     &AttrSelector{key: "data-x", op: "=", val: "a.b"},
+		},
+		{
+			name:     "parse an attribute sel with escaped quotes in quoted value",
+			selector: `[title="say \"hi\" it's"]`,
+			want:     &AttrSelector{key: "title", op: "=", val: `say "hi" it's`},
+		},
+		{
+			name:     "parse an attribute sel with escaped single quote in single quoted value",
+			selector: `[title='it\'s']`,
+			want:     &AttrSelector{key: "title", op: "=", val: "it's"},
+		},
+		{
+			name:     "parse an attribute sel with escaped new line in quoted value",
+			selector: "[title=\"a\\\nb\"]",
+			want:     &AttrSelector{key: "title", op: "=", val: "ab"},
+		},
+		{
+			name:     "parse an attribute sel with escaped \\r\\n in quoted value",
+			selector: "[title=\"a\\\r\nb\"]",
+			want:     &AttrSelector{key: "title", op: "=", val: "ab"},
+		},
+		{
+			name:     "parse an attribute sel with closing bracket in quoted value",
+			selector: `[title="a]b"]`,
+			want:     &AttrSelector{key: "title", op: "=", val: "a]b"},
+		},
+		{
+			name:     "throw error for new line in quoted value",
+			selector: "[title=\"a\nb\"]",
+			want:     nil,
+		},
+		{
+			name:     "throw error for unterminated quoted value",
+			selector: `[title="a]`,
+			want:     nil,
+		},
+		{
+			name:     "throw error for unknown modifier",
+			selector: `[key="val" x]`,
+			want:     nil,
+		},
+		{
+			name:     "throw error for modifier without operation",
+			selector: `[key i]`,
+			want:     nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(
@@ -155,6 +230,144 @@ func TestAttributeParser_Match(t *testing.T) {
 			id:   `[title*="class"]`,
 			want: true,
 		},
+		{
+			name: "not match element with attribute 'width' for <input height='100px'/>",
+			html: `<input height="100px"/>`,
+			id:   `[width]`,
+			want: false,
+		},
+		{
+			name: "match element with attribute 'width' when it is not the first attribute for <input height='1px' width='2px'/>",
+			html: `<input height="1px" width="2px"/>`,
+			id:   `[width="2px"]`,
+			want: true,
+		},
+		{
+			name: "not match element with attribute 'title' that contains the word 'sub' for <input title='un substring'/>",
+			html: `<input title="un substring"/>`,
+			id:   `[title~="sub"]`,
+			want: false,
+		},
+		{
+			name: "not match element with attribute 'title' that contains the empty word for <input title=''/>",
+			html: `<input title=""/>`,
+			id:   `[title~=""]`,
+			want: false,
+		},
+		{
+			name: "not match element with attribute 'title' that starts with 'es-' for <input title='esES'/>",
+			html: `<input title="esES"/>`,
+			id:   `[title|="es"]`,
+			want: false,
+		},
+		{
+			name: "not match element with attribute 'href' that begins with 'https' for <a href='http'/>",
+			html: `<a href="http"/>`,
+			id:   `[href^="https"]`,
+			want: false,
+		},
+		{
+			name: "not match element with attribute 'href' that ends with 'https' for <a href='http'/>",
+			html: `<a href="http"/>`,
+			id:   `[href$="https"]`,
+			want: false,
+		},
+		{
+			name: "not match element with attribute 'title' that begins with empty value for <input title='es'/>",
+			html: `<input title="es"/>`,
+			id:   `[title^=""]`,
+			want: false,
+		},
+		{
+			name: "not match element with attribute 'title' that ends with empty value for <input title='es'/>",
+			html: `<input title="es"/>`,
+			id:   `[title$=""]`,
+			want: false,
+		},
+		{
+			name: "not match element with attribute 'title' that contains empty value for <input title='es'/>",
+			html: `<input title="es"/>`,
+			id:   `[title*=""]`,
+			want: false,
+		},
+		{
+			name: "match element with attribute 'title' with empty value for <input title=''/>",
+			html: `<input title=""/>`,
+			id:   `[title=""]`,
+			want: true,
+		},
+		{
+			name: "match element with attribute 'title' with value 'Hello world' for <p title='Hello world'/>",
+			html: `<p title="Hello world"></p>`,
+			id:   `[title="Hello world"]`,
+			want: true,
+		},
+		{
+			name: "match element with attribute 'data-x' with value 'a.b' for <p data-x='a.b'/>",
+			html: `<p data-x="a.b"></p>`,
+			id:   `[data-x='a.b']`,
+			want: true,
+		},
+		{
+			name: "match element with attribute 'type' with value 'SUBMIT' ignoring case for <input type='submit'/>",
+			html: `<input type="submit"/>`,
+			id:   `[type="SUBMIT" i]`,
+			want: true,
+		},
+		{
+			name: "match element with attribute 'data-x' that begins with 'AB' ignoring case for <input data-x='abc'/>",
+			html: `<input data-x="abc"/>`,
+			id:   `[data-x^="AB" i]`,
+			want: true,
+		},
+		{
+			name: "not match element with attribute 'data-x' with value 'ABC' for <input data-x='abc'/>",
+			html: `<input data-x="abc"/>`,
+			id:   `[data-x="ABC"]`,
+			want: false,
+		},
+		{
+			name: "match element with attribute 'type' with value 'SUBMIT' by default for <input type='submit'/>",
+			html: `<input type="submit"/>`,
+			id:   `[type="SUBMIT"]`,
+			want: true,
+		},
+		{
+			name: "match element with attribute 'lang' with value 'EN' by default for <p lang='en-US'/>",
+			html: `<p lang="en-US"></p>`,
+			id:   `[lang|="EN"]`,
+			want: true,
+		},
+		{
+			name: "not match element with attribute 'type' with value 'SUBMIT' case-sensitive for <input type='submit'/>",
+			html: `<input type="submit"/>`,
+			id:   `[type="SUBMIT" s]`,
+			want: false,
+		},
+		{
+			name: "match element with attribute 'WIDTH' ignoring name case for <input width='100px'/>",
+			html: `<input width="100px"/>`,
+			id:   `[WIDTH]`,
+			want: true,
+		},
+		{
+			name: "match element with attribute 'TYPE' with value 'SUBMIT' by default for <input type='submit'/>",
+			html: `<input type="submit"/>`,
+			id:   `[TYPE="SUBMIT"]`,
+			want: true,
+		},
+		{
+			name: "match element with attribute 'viewBox' for <svg viewBox='0 0 1 1'>",
+			html: `<svg viewBox="0 0 1 1"></svg>`,
+			id:   `[viewBox]`,
+			want: true,
+		},
+		{
+			name: "not match element with attribute 'viewbox' case-sensitive for <svg viewBox='0 0 1 1'>",
+			html: `<svg viewBox="0 0 1 1"></svg>`,
+			id:   `[viewbox]`,
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t1 *testing.T) {
@@ -0,0 +1,94 @@
+package selector
+
+import (
+	"container/list"
+	"sync"
+)
+
+// CacheStats counts the lookups of a Cache
+type CacheStats struct {
+	// Hits is the number of selectors found already compiled
+	Hits uint64
+	// Misses is the number of selectors compiled because they were not found
+	Misses uint64
+}
+
+// Cache memoizes the selectors compiled by a Compiler keyed by their text, keeping the most recently used ones
+// up to its size, it is safe for concurrent use
+type Cache struct {
+	compiler Compiler
+	size     int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	recent  *list.List // entries from the most to the least recently used
+	stats   CacheStats
+}
+
+type cacheEntry struct {
+	sel      string
+	compiled Sel
+	err      error
+}
+
+// NewCache returns a Cache compiling with c that keeps at most size selectors, every selector when size is not positive
+func NewCache(c Compiler, size int) *Cache {
+	return &Cache{
+		compiler: c,
+		size:     size,
+		entries:  map[string]*list.Element{},
+		recent:   list.New(),
+	}
+}
+
+// Compile returns the compiled sel, or the error found compiling it, compiling it only when it is not cached
+func (c *Cache) Compile(sel string) (Sel, error) {
+	c.mu.Lock()
+	if e, ok := c.entries[sel]; ok {
+		c.stats.Hits++
+		c.recent.MoveToFront(e)
+		c.mu.Unlock()
+
+		entry := e.Value.(*cacheEntry)
+		return entry.compiled, entry.err
+	}
+	c.stats.Misses++
+	c.mu.Unlock()
+
+	// compiling without the lock lets other selectors be compiled meanwhile, the selectors are never modified
+	// after compiling so the same one can be shared by every caller
+	compiled, err := c.compiler.Compile(sel)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[sel]; ok { // compiled meanwhile by other caller
+		c.recent.MoveToFront(e)
+		entry := e.Value.(*cacheEntry)
+		return entry.compiled, entry.err
+	}
+
+	c.entries[sel] = c.recent.PushFront(&cacheEntry{sel: sel, compiled: compiled, err: err})
+	if c.size > 0 && c.recent.Len() > c.size {
+		oldest := c.recent.Back()
+		c.recent.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).sel)
+	}
+
+	return compiled, err
+}
+
+// Stats returns the hits and misses of the lookups done so far
+func (c *Cache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.stats
+}
+
+// Len returns the number of cached selectors
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.recent.Len()
+}
@@ -0,0 +1,80 @@
+package selector
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestCache_Compile(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      int
+		selectors []string
+		want      CacheStats
+		wantLen   int
+	}{
+		{name: "miss the first time", size: 2, selectors: []string{"li"}, want: CacheStats{Misses: 1}, wantLen: 1},
+		{name: "hit the same selector", size: 2, selectors: []string{"li", "li", "li"}, want: CacheStats{Hits: 2, Misses: 1}, wantLen: 1},
+		{name: "miss evicted selector", size: 2, selectors: []string{"li", "ul", "p", "li"}, want: CacheStats{Misses: 4}, wantLen: 2},
+		{name: "hit recently used selector", size: 2, selectors: []string{"li", "ul", "li", "p", "li"}, want: CacheStats{Hits: 2, Misses: 3}, wantLen: 2},
+		{name: "miss least recently used selector", size: 2, selectors: []string{"li", "ul", "li", "p", "ul"}, want: CacheStats{Hits: 1, Misses: 4}, wantLen: 2},
+		{name: "hit invalid selector", size: 2, selectors: []string{"#", "#"}, want: CacheStats{Hits: 1, Misses: 1}, wantLen: 1},
+		{name: "keep every selector without size", size: 0, selectors: []string{"li", "ul", "p", "li"}, want: CacheStats{Hits: 1, Misses: 3}, wantLen: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			c := NewCache(Compiler{}, tt.size)
+			for _, sel := range tt.selectors {
+				c.Compile(sel)
+			}
+			if got := c.Stats(); !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("Stats() = %+v, want %+v", got, tt.want)
+			}
+			if got := c.Len(); got != tt.wantLen {
+				t1.Errorf("Len() = %v, want %v", got, tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestCache_Compile_Result(t *testing.T) {
+	c := NewCache(Compiler{Extensions: true}, 10)
+	for _, sel := range []string{"ul > li.item", ":contains(text)", "#", "a:unknown"} {
+		want, wantErr := Compiler{Extensions: true}.Compile(sel)
+		for i := 0; i < 2; i++ {
+			got, err := c.Compile(sel)
+			if !reflect.DeepEqual(got, want) || !reflect.DeepEqual(err, wantErr) {
+				t.Errorf("Compile(%q) = %v, %v, want %v, %v", sel, got, err, want, wantErr)
+			}
+		}
+	}
+}
+
+func TestCache_Compile_Concurrent(t *testing.T) {
+	c := NewCache(Compiler{}, 3)
+	selectors := []string{"li", "ul > li", ".item", "#first", "h1, h2"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				sel := selectors[j%len(selectors)]
+				if s, err := c.Compile(sel); err != nil || s.String() != sel {
+					t.Errorf("Compile(%q) = %v, %v", sel, s, err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if stats := c.Stats(); stats.Hits+stats.Misses != 8000 {
+		t.Errorf("Stats() = %+v, want 8000 lookups", stats)
+	}
+	if got := c.Len(); got > 3 {
+		t.Errorf("Len() = %v, want at most 3", got)
+	}
+}
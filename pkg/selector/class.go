@@ -1,7 +1,7 @@
 package selector
 
 import (
-	"fmt"
+	"strings"
 
 	"golang.org/x/net/html"
 )
@@ -13,8 +13,14 @@ type ClassSelector struct {
 func (t ClassSelector) Match(n *html.Node) bool {
 	if html.ElementNode == n.Type {
 		for _, attr := range n.Attr {
-			if "class" == attr.Key && t.class == attr.Val {
-				return true
+			if "class" != attr.Key {
+				continue
+			}
+
+			for _, class := range strings.FieldsFunc(attr.Val, isASCIIWhitespace) {
+				if t.class == class {
+					return true
+				}
 			}
 		}
 	}
@@ -22,13 +28,21 @@ func (t ClassSelector) Match(n *html.Node) bool {
 	return false
 }
 
+func (t ClassSelector) Specificity() [3]int {
+	return [3]int{0, 1, 0}
+}
+
+func (t ClassSelector) String() string {
+	return "." + serializeIdentifier(t.class)
+}
+
 type ClassParser struct {
-	SelParser
+	*SelParser
 }
 
 func NewClassParser(sel string) *ClassParser {
 	return &ClassParser{
-		SelParser{
+		&SelParser{
 			sel:    sel,
 			selLen: len(sel),
 		},
@@ -36,34 +50,26 @@ func NewClassParser(sel string) *ClassParser {
 }
 
 func (c ClassParser) Parse() (Sel, error) {
-	if c.sel[c.pos] != '.' {
-		return nil, fmt.Errorf("expected key selector (.key), found '%c'", c.sel[c.pos])
+	if c.pos >= c.selLen || c.sel[c.pos] != '.' {
+		return nil, c.newError("class selector (.class)")
 	}
 	c.pos++
+	if !c.isIdentifierStart(c.pos) { // the class must be an identifier, so it can not start with a digit
+		return nil, c.newError("class name")
+	}
 
-	class := ""
-	for c.pos < c.selLen {
-		char := c.sel[c.pos]
-
-		switch {
-		case c.isValidIdentifierChar(char): // get current "c" if is a valid name character
-			class += string(char)
-			c.pos++
-			break
-		case char == '\\': // sel have an escaped element https://drafts.csswg.org/css-syntax-3/#escaping
-			c, err := c.parseEscape()
-			if err != nil {
-				return nil, err
-			}
-			class += c
-			break
-		case char == ' ':
-			c.pos++
-			break
-		}
+	class, err := c.parseIdentifier()
+	if err != nil {
+		return nil, err
 	}
 
 	return &ClassSelector{
 		class: class,
 	}, nil
 }
+
+// isASCIIWhitespace checks if is a character that separates the tokens of the class attribute
+// as defined in https://infra.spec.whatwg.org/#ascii-whitespace
+func isASCIIWhitespace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\f' || r == '\r'
+}
@@ -41,24 +41,44 @@ func TestClassParser_Parse(t *testing.T) {
 			want:     &ClassSelector{class: "testid"},
 		},
 		{
-			name:     "parse key sel with escaped UNICODE element '\\U+000069' (s)",
-			selector: `.test\U+000069d`,
-			want:     &ClassSelector{class: "testid"},
+			name:     "parse key sel with '\\U+' escaping only the letter U",
+			selector: `.test\U+0069 d`,
+			want:     &ClassSelector{class: "testU"},
 		},
 		{
-			name:     "parse key sel with multiple escaped UNICODE element '\\U+000065' (s) and '\\U+000069' (i)",
-			selector: `.t\U+000065st\U+000069d`,
-			want:     &ClassSelector{class: "testid"},
+			name:     "parse key sel with escaped non-hex characters '\\.' and '\\:'",
+			selector: `.a\.b\:c`,
+			want:     &ClassSelector{class: "a.b:c"},
 		},
 		{
-			name:     "parse key sel with escaped UNICODE element with 4 digits and whitespace '\\U+0073' (s)",
-			selector: `.test\U+0069 d`,
+			name:     "parse key sel with escaped element and \\r\\n as its whitespace",
+			selector: ".test\\69\r\nd",
 			want:     &ClassSelector{class: "testid"},
 		},
 		{
-			name:     "parse key sel with multiple escaped UNICODE element with 4 digits and whitespace '\\U+0073' (s) and '\\U+0069' (i)",
-			selector: `.t\U+0065 st\U+0069 d`,
-			want:     &ClassSelector{class: "testid"},
+			name:     "parse key sel with escaped zero as replacement character",
+			selector: `.test\0 d`,
+			want:     &ClassSelector{class: "test\ufffdd"},
+		},
+		{
+			name:     "parse key sel with escaped surrogate as replacement character",
+			selector: `.test\d800 d`,
+			want:     &ClassSelector{class: "test\ufffdd"},
+		},
+		{
+			name:     "parse key sel with escaped code point out of range as replacement character",
+			selector: `.test\110000d`,
+			want:     &ClassSelector{class: "test\ufffdd"},
+		},
+		{
+			name:     "parse key sel with escape at end as replacement character",
+			selector: `.test\`,
+			want:     &ClassSelector{class: "test\ufffd"},
+		},
+		{
+			name:     "parse key sel ending at escaped new line",
+			selector: ".test\\\nd",
+			want:     &ClassSelector{class: "test"},
 		},
 	}
 	for _, tt := range tests {
@@ -86,6 +106,30 @@ func TestClassParser_Match(t *testing.T) {
 			id:   ".match-class",
 			want: true,
 		},
+		{
+			name: "match element with class 'match-class' for <input class='a match-class b'/>",
+			html: `<input class='a match-class b'/>`,
+			id:   ".match-class",
+			want: true,
+		},
+		{
+			name: "match element with class 'match-class' separated by tabs and new lines for <input class='a\tmatch-class\nb'/>",
+			html: "<input class='a\tmatch-class\nb'/>",
+			id:   ".match-class",
+			want: true,
+		},
+		{
+			name: "not match element with class 'match' for <input class='match-class'/>",
+			html: `<input class='match-class'/>`,
+			id:   ".match",
+			want: false,
+		},
+		{
+			name: "not match element with class 'a b' for <input class='a b'/>",
+			html: `<input class='a b'/>`,
+			id:   `.a\20 b`,
+			want: false,
+		},
 		{
 			name: "not match element with class 'match-class' for <input class='no-match-class'/>",
 			html: `<input class='no-match-class'/>`,
@@ -0,0 +1,160 @@
+package selector
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+type CombinatorSelector struct {
+	left       Sel
+	combinator byte
+	right      Sel
+}
+
+func (t CombinatorSelector) Match(n *html.Node) bool {
+	return t.matchAnchored(n, nil)
+}
+
+func (t CombinatorSelector) Specificity() [3]int {
+	return addSpecificity(t.left.Specificity(), t.right.Specificity())
+}
+
+func (t CombinatorSelector) String() string {
+	combinator := " " + string(t.combinator) + " "
+	if t.combinator == ' ' {
+		combinator = " "
+	}
+
+	if _, ok := t.left.(*anchorSelector); ok { // relative selectors start with the combinator
+		return strings.TrimLeft(combinator, " ") + t.right.String()
+	}
+
+	return t.left.String() + combinator + t.right.String()
+}
+
+// matchResult tells how far a failed right-to-left match has to backtrack, so chains of combinators like
+// 'div .a span' try each ancestor once instead of walking the ancestors again for every candidate
+// as done by browser engines https://github.com/servo/servo/blob/main/components/selectors/matching.rs
+type matchResult int
+
+const (
+	matched matchResult = iota
+	// notMatchedRestartFromLaterSibling lets the closest '~' or ' ' combinator try its next candidate
+	notMatchedRestartFromLaterSibling
+	// notMatchedRestartFromDescendant lets only the closest ' ' combinator try its next candidate
+	notMatchedRestartFromDescendant
+	// notMatchedGlobally stops the match, no other candidate can match
+	notMatchedGlobally
+)
+
+// matchAnchored matches n where the anchorSelector on the left side only matches anchor
+func (t CombinatorSelector) matchAnchored(n, anchor *html.Node) bool {
+	return t.matchRightToLeft(n, anchor) == matched
+}
+
+// matchRightToLeft matches the right side against n and then the left side against the candidates of the combinator,
+// stopping as soon as the result shows that the remaining candidates can not match
+func (t CombinatorSelector) matchRightToLeft(n, anchor *html.Node) matchResult {
+	if html.ElementNode != n.Type || !t.right.Match(n) {
+		return notMatchedRestartFromLaterSibling
+	}
+
+	var candidate func(n *html.Node) *html.Node
+	notFound := notMatchedGlobally
+	switch t.combinator {
+	case '>', ' ': // child and descendant combinators https://drafts.csswg.org/selectors-4/#child-combinators
+		candidate = parentElement
+	case '+', '~': // sibling combinators https://drafts.csswg.org/selectors-4/#adjacent-sibling-combinators
+		candidate = previousElementSibling
+		notFound = notMatchedRestartFromDescendant
+	default:
+		return notMatchedGlobally
+	}
+
+	for c := candidate(n); c != nil; c = candidate(c) {
+		result := matchRightToLeft(t.left, c, anchor)
+		switch {
+		case result == matched || result == notMatchedGlobally || t.combinator == '+':
+			return result
+		case t.combinator == '>':
+			return notMatchedRestartFromDescendant
+		case t.combinator == '~' && result == notMatchedRestartFromDescendant:
+			return result
+		}
+	}
+
+	return notFound
+}
+
+// anchorSelector represents the element that relative selectors are relative to
+// as defined in https://drafts.csswg.org/selectors-4/#relative
+type anchorSelector struct{}
+
+func (t anchorSelector) Match(_ *html.Node) bool {
+	return false
+}
+
+func (t anchorSelector) Specificity() [3]int {
+	return [3]int{0, 0, 0}
+}
+
+func (t anchorSelector) String() string {
+	return ""
+}
+
+// matchAnchored matches s against n resolving the anchorSelector to anchor
+func matchAnchored(s Sel, n, anchor *html.Node) bool {
+	return matchRightToLeft(s, n, anchor) == matched
+}
+
+// matchRightToLeft matches s against n resolving the anchorSelector to anchor, a failure of the leftmost
+// selector lets the combinators try their next candidate
+func matchRightToLeft(s Sel, n, anchor *html.Node) matchResult {
+	switch t := s.(type) {
+	case *anchorSelector:
+		if n == anchor {
+			return matched
+		}
+		return notMatchedRestartFromLaterSibling
+	case *CombinatorSelector:
+		return t.matchRightToLeft(n, anchor)
+	}
+
+	if s.Match(n) {
+		return matched
+	}
+
+	return notMatchedRestartFromLaterSibling
+}
+
+// parentElement returns the parent of n when it is an element
+func parentElement(n *html.Node) *html.Node {
+	if p := n.Parent; p != nil && html.ElementNode == p.Type {
+		return p
+	}
+
+	return nil
+}
+
+// previousElementSibling returns the closest previous sibling that is an element, skipping text and comments
+func previousElementSibling(n *html.Node) *html.Node {
+	for p := n.PrevSibling; p != nil; p = p.PrevSibling {
+		if html.ElementNode == p.Type {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// nextElementSibling returns the closest next sibling that is an element, skipping text and comments
+func nextElementSibling(n *html.Node) *html.Node {
+	for p := n.NextSibling; p != nil; p = p.NextSibling {
+		if html.ElementNode == p.Type {
+			return p
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package selector
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+// findElement returns the first element in document order with the given id
+func findElement(n *html.Node, id string) *html.Node {
+	for _, attr := range n.Attr {
+		if "id" == attr.Key && id == attr.Val {
+			return n
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if e := findElement(c, id); e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
+
+func TestCombinatorSelector_Match(t *testing.T) {
+	doc := `<html><body><ul id="list"><li id="first"><span id="span"></span></li></ul><p id="p"></p></body></html>`
+	table := `<table><tr><td class="label" id="label">Name</td> text <!-- comment --> <td id="value">Value</td><td id="last">Last</td></tr></table>`
+	nested := `<div class="a"><div class="b"><div class="b"><p><span class="c" id="c"></span></p></div></div></div>`
+	siblings := `<i class="a"></i><i class="b"></i><i class="x"></i><i class="b"></i><i class="c" id="c"></i>` +
+		`<div class="y"><p><span class="z" id="z"></span></p></div>`
+	tests := []struct {
+		name     string
+		html     string
+		selector string
+		id       string
+		want     bool
+	}{
+		{name: "match 'ul#list > li' for child <li>", html: doc, selector: "ul#list > li", id: "first", want: true},
+		{name: "not match 'body > li' for grandchild <li>", html: doc, selector: "body > li", id: "first", want: false},
+		{name: "match 'body li' for descendant <li>", html: doc, selector: "body li", id: "first", want: true},
+		{name: "match 'ul span' for deep descendant <span>", html: doc, selector: "ul span", id: "span", want: true},
+		{name: "not match 'ul span' for <p> outside of <ul>", html: doc, selector: "ul p", id: "p", want: false},
+		{name: "match 'body > ul > li > span' for <span>", html: doc, selector: "body > ul > li > span", id: "span", want: true},
+		{name: "match 'html ul > li span' for <span>", html: doc, selector: "html ul > li span", id: "span", want: true},
+		{name: "not match '* > html' for root element", html: doc, selector: "* > html", id: "", want: false},
+		{name: "match 'td.label + td' for adjacent <td> after text and comments", html: table, selector: "td.label + td", id: "value", want: true},
+		{name: "not match 'td.label + td' for non adjacent <td>", html: table, selector: "td.label + td", id: "last", want: false},
+		{name: "match 'td.label ~ td' for subsequent <td>", html: table, selector: "td.label ~ td", id: "last", want: true},
+		{name: "not match 'td ~ td.label' for first <td>", html: table, selector: "td ~ td.label", id: "label", want: false},
+		{name: "match 'tr > td + td ~ td' for last <td>", html: table, selector: "tr > td + td ~ td", id: "last", want: true},
+		{name: "match '.a > .b .c' retrying the farther .b", html: nested, selector: ".a > .b .c", id: "c", want: true},
+		{name: "not match '.a .b > .c' for grandchild of .b", html: nested, selector: ".a .b > .c", id: "c", want: false},
+		{name: "match '.a + .b ~ .c' retrying the farther .b", html: siblings, selector: ".a + .b ~ .c", id: "c", want: true},
+		{name: "not match '.b + .b ~ .c' without adjacent .b", html: siblings, selector: ".b + .b ~ .c", id: "c", want: false},
+		{name: "match '.x ~ .y .z' for descendant of sibling", html: siblings, selector: ".x ~ .y .z", id: "z", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			root, _ := html.Parse(strings.NewReader(tt.html))
+			n := root.FirstChild
+			if tt.id != "" {
+				n = findElement(root, tt.id)
+			}
+			got, err := Compile(tt.selector)
+			if err != nil {
+				t1.Errorf("Compile() error = %v", err)
+				return
+			}
+			if res := got.Match(n); res != tt.want {
+				t1.Errorf("Match() = %v, want %v for selector %s", res, tt.want, tt.selector)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package selector
+
+// simpleSelectorExpected lists the selectors accepted at the start of a compound selector
+var simpleSelectorExpected = []string{"type selector (tag)", "universal selector (*)", "id selector (#id)",
+	"class selector (.class)", "attribute selector ([attr])", "pseudo-class selector (:name)"}
+
+type SelectorParser struct {
+	*SelParser
+}
+
+func NewSelectorParser(sel string) *SelectorParser {
+	return &SelectorParser{
+		&SelParser{
+			sel:    sel,
+			selLen: len(sel),
+		},
+	}
+}
+
+// Compiler holds the options used to compile selectors
+type Compiler struct {
+	// Extensions enables the non-standard pseudo-classes :contains(), :containsOwn() and :matches()
+	Extensions bool
+	// Namespaces maps the prefixes of 'ns|tag' and '[ns|attr]' to namespace URIs like @namespace rules do,
+	// the empty prefix declares the default namespace of the type selectors
+	Namespaces map[string]string
+}
+
+// Compile parses a full selector string into a Sel using the compiler options
+func (c Compiler) Compile(sel string) (Sel, error) {
+	p := NewSelectorParser(sel)
+	p.extensions = c.Extensions
+	p.namespaces = c.Namespaces
+
+	return p.Parse()
+}
+
+// Compile parses a full selector string into a Sel with the standard selectors only
+func Compile(sel string) (Sel, error) {
+	return Compiler{}.Compile(sel)
+}
+
+func (s *SelectorParser) Parse() (Sel, error) {
+	sel, err := s.parseList()
+	if err != nil {
+		return nil, err
+	}
+
+	if s.pos < s.selLen {
+		return nil, s.newError("combinator (' ', >, + or ~)", "selector list separator (,)", "end of selector")
+	}
+
+	return sel, nil
+}
+
+// parseList parses comma separated complex selectors, the list matches if any of them matches
+func (s *SelectorParser) parseList() (Sel, error) {
+	sels, err := s.parseCommaSeparated(s.parseComplex)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sels) == 1 {
+		return sels[0], nil
+	}
+
+	return &SelectorList{
+		sels: sels,
+	}, nil
+}
+
+// parseCommaSeparated parses selectors with parse until there is no comma after them
+func (s *SelectorParser) parseCommaSeparated(parse func() (Sel, error)) ([]Sel, error) {
+	var sels []Sel
+	for {
+		s.skipWhitespace()
+		if s.pos >= s.selLen {
+			return nil, s.newError(simpleSelectorExpected...)
+		}
+
+		sel, err := parse()
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+
+		if s.pos >= s.selLen || s.sel[s.pos] != ',' {
+			return sels, nil
+		}
+		s.pos++
+	}
+}
+
+// parseComplex parses compound selectors joined by combinators, the trailing whitespace is consumed
+func (s *SelectorParser) parseComplex() (Sel, error) {
+	sel, err := s.parseCompound()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.parseCombinators(sel)
+}
+
+// parseRelative parses a complex selector starting with an optional combinator (formats '> img' or 'img'),
+// the combinator relates the selector to the anchor element
+// as defined in https://drafts.csswg.org/selectors-4/#relative
+func (s *SelectorParser) parseRelative() (Sel, error) {
+	combinator := byte(' ')
+	if s.pos < s.selLen && (s.sel[s.pos] == '>' || s.sel[s.pos] == '+' || s.sel[s.pos] == '~') {
+		combinator = s.sel[s.pos]
+		s.pos++
+		s.skipWhitespace()
+	}
+
+	right, err := s.parseCompound()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.parseCombinators(&CombinatorSelector{
+		left:       &anchorSelector{},
+		combinator: combinator,
+		right:      right,
+	})
+}
+
+// parseCombinators parses the combinators and compound selectors following sel
+func (s *SelectorParser) parseCombinators(sel Sel) (Sel, error) {
+	for s.pos < s.selLen {
+		combinator := byte(' ')
+		hasWhitespace := s.skipWhitespace()
+		if s.pos >= s.selLen {
+			break
+		}
+
+		switch char := s.sel[s.pos]; {
+		case char == ',' || char == ')': // end of the selector inside a selector list or a pseudo-class
+			return sel, nil
+		case char == '>' || char == '+' || char == '~':
+			combinator = char
+			s.pos++
+			s.skipWhitespace()
+		case !hasWhitespace:
+			return sel, nil
+		}
+
+		right, err := s.parseCompound()
+		if err != nil {
+			return nil, err
+		}
+		sel = &CombinatorSelector{
+			left:       sel,
+			combinator: combinator,
+			right:      right,
+		}
+	}
+
+	return sel, nil
+}
+
+// parseCompound parses a sequence of simple selectors that must match the same element
+func (s *SelectorParser) parseCompound() (Sel, error) {
+	var sels []Sel
+	for s.pos < s.selLen && s.isSimpleSelectorStart(s.pos) {
+		if len(sels) > 0 && s.isTypeSelectorStart(s.pos) {
+			return nil, s.newError("id, class, attribute or pseudo-class selector after the type selector")
+		}
+
+		sel, err := s.parseSimple()
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+	}
+
+	if len(sels) == 0 {
+		return nil, s.newError(simpleSelectorExpected...)
+	}
+	if len(sels) == 1 {
+		return sels[0], nil
+	}
+
+	return &CompoundSelector{
+		sels: sels,
+	}, nil
+}
+
+// parseSimple picks the sub-parser for the selector that starts at the current position
+func (s *SelectorParser) parseSimple() (Sel, error) {
+	var p Parser
+
+	if s.pos >= s.selLen {
+		return nil, s.newError(simpleSelectorExpected...)
+	}
+
+	char := s.sel[s.pos]
+	switch {
+	case char == '#':
+		p = &IdParser{s.SelParser}
+	case char == '.':
+		p = &ClassParser{s.SelParser}
+	case char == '[':
+		p = &AttributeParser{s.SelParser}
+	case char == ':':
+		p = &PseudoParser{s.SelParser}
+	case s.isTypeSelectorStart(s.pos) && s.isUniversalSelector():
+		p = &UniversalParser{s.SelParser}
+	case s.isTypeSelectorStart(s.pos):
+		p = &TagParser{s.SelParser}
+	default:
+		return nil, s.newError(simpleSelectorExpected...)
+	}
+
+	return p.Parse()
+}
+
+// isSimpleSelectorStart checks if the characters at pos begin a simple selector
+func (s SelectorParser) isSimpleSelectorStart(pos int) bool {
+	char := s.sel[pos]
+	return s.isTypeSelectorStart(pos) || char == '#' || char == '.' || char == '[' || char == ':'
+}
+
+// isTypeSelectorStart checks if the characters at pos begin a type (tag) or universal selector,
+// including their namespace prefix
+func (s SelectorParser) isTypeSelectorStart(pos int) bool {
+	char := s.sel[pos]
+	return s.isIdentifierStart(pos) || char == '*' || char == '|'
+}
+
+// isUniversalSelector checks if the selector at the current position is a universal selector (formats '*' and 'ns|*')
+func (s SelectorParser) isUniversalSelector() bool {
+	start := s.pos
+	s.scanNamespacePrefix()
+	universal := s.pos < s.selLen && s.sel[s.pos] == '*'
+	s.pos = start
+
+	return universal
+}
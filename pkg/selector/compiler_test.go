@@ -0,0 +1,239 @@
+package selector
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     Sel
+		wantErr  bool
+	}{
+		{name: "compile a tag selector", selector: "ul", want: &TagSelector{tag: "ul", name: "ul", atom: atom.Ul}},
+		{name: "compile an id selector", selector: "#list", want: &IdSelector{id: "list"}},
+		{name: "compile a class selector", selector: ".pretty-list", want: &ClassSelector{class: "pretty-list"}},
+		{name: "compile an attribute selector", selector: "[key=val]", want: &AttrSelector{key: "key", op: "=", val: "val"}},
+		{name: "compile a universal selector", selector: "*", want: &UniversalSelector{}},
+		{name: "compile an escaped tag selector", selector: `\75 l`, want: &TagSelector{tag: "ul", name: "ul", atom: atom.Ul}},
+		{
+			name:     "compile a compound selector",
+			selector: `li.pretty-element-list[data-x="1"]#first`,
+			want: &CompoundSelector{sels: []Sel{
+				&TagSelector{tag: "li", name: "li", atom: atom.Li},
+				&ClassSelector{class: "pretty-element-list"},
+				&AttrSelector{key: "data-x", op: "=", val: "1"},
+				&IdSelector{id: "first"},
+			}},
+		},
+		{
+			name:     "compile a compound selector without type selector",
+			selector: ".a#b",
+			want:     &CompoundSelector{sels: []Sel{&ClassSelector{class: "a"}, &IdSelector{id: "b"}}},
+		},
+		{
+			name:     "compile a child combinator",
+			selector: "ul#list > li",
+			want: &CombinatorSelector{
+				left:       &CompoundSelector{sels: []Sel{&TagSelector{tag: "ul", name: "ul", atom: atom.Ul}, &IdSelector{id: "list"}}},
+				combinator: '>',
+				right:      &TagSelector{tag: "li", name: "li", atom: atom.Li},
+			},
+		},
+		{
+			name:     "compile a descendant combinator surrounded by whitespace",
+			selector: " body\n\tli ",
+			want: &CombinatorSelector{
+				left:       &TagSelector{tag: "body", name: "body", atom: atom.Body},
+				combinator: ' ',
+				right:      &TagSelector{tag: "li", name: "li", atom: atom.Li},
+			},
+		},
+		{
+			name:     "compile chained combinators from left to right",
+			selector: "body ul>li",
+			want: &CombinatorSelector{
+				left: &CombinatorSelector{
+					left:       &TagSelector{tag: "body", name: "body", atom: atom.Body},
+					combinator: ' ',
+					right:      &TagSelector{tag: "ul", name: "ul", atom: atom.Ul},
+				},
+				combinator: '>',
+				right:      &TagSelector{tag: "li", name: "li", atom: atom.Li},
+			},
+		},
+		{
+			name:     "compile sibling combinators",
+			selector: "td.label + td ~ td",
+			want: &CombinatorSelector{
+				left: &CombinatorSelector{
+					left:       &CompoundSelector{sels: []Sel{&TagSelector{tag: "td", name: "td", atom: atom.Td}, &ClassSelector{class: "label"}}},
+					combinator: '+',
+					right:      &TagSelector{tag: "td", name: "td", atom: atom.Td},
+				},
+				combinator: '~',
+				right:      &TagSelector{tag: "td", name: "td", atom: atom.Td},
+			},
+		},
+		{
+			name:     "compile a selector list",
+			selector: "h1, h2,.title",
+			want: &SelectorList{sels: []Sel{
+				&TagSelector{tag: "h1", name: "h1", atom: atom.H1},
+				&TagSelector{tag: "h2", name: "h2", atom: atom.H2},
+				&ClassSelector{class: "title"},
+			}},
+		},
+		{
+			name:     "compile a selector list of complex selectors",
+			selector: "ul > li , p span",
+			want: &SelectorList{sels: []Sel{
+				&CombinatorSelector{left: &TagSelector{tag: "ul", name: "ul", atom: atom.Ul}, combinator: '>', right: &TagSelector{tag: "li", name: "li", atom: atom.Li}},
+				&CombinatorSelector{left: &TagSelector{tag: "p", name: "p", atom: atom.P}, combinator: ' ', right: &TagSelector{tag: "span", name: "span", atom: atom.Span}},
+			}},
+		},
+		{name: "throw error for selector list with empty selector", selector: "h1,,h2", want: nil, wantErr: true},
+		{name: "throw error for selector list with trailing comma", selector: "h1, ", want: nil, wantErr: true},
+		{name: "throw error for combinator without right selector", selector: "ul >", want: nil, wantErr: true},
+		{name: "throw error for combinator without left selector", selector: "> li", want: nil, wantErr: true},
+		{name: "throw error for type selector after other simple selectors", selector: ".a*", want: nil, wantErr: true},
+		{name: "throw error for empty selector", selector: "", want: nil, wantErr: true},
+		{name: "throw error for unknown selector", selector: "!ul", want: nil, wantErr: true},
+		{name: "throw error for trailing input", selector: "*!", want: nil, wantErr: true},
+		{name: "throw error for unclosed attribute selector", selector: "a[key", want: nil, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			got, err := Compile(tt.selector)
+			if (err != nil) != tt.wantErr {
+				t1.Errorf("Compile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("Compile() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompile_Match(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		selector string
+		want     bool
+	}{
+		{
+			name:     "match 'ul' selector for <ul id='list'>",
+			html:     `<ul id="list"></ul>`,
+			selector: "ul",
+			want:     true,
+		},
+		{
+			name:     "match '#list' selector for <ul id='list'>",
+			html:     `<ul id="list"></ul>`,
+			selector: "#list",
+			want:     true,
+		},
+		{
+			name:     "match 'ul#list' selector for <ul id='list'>",
+			html:     `<ul id="list"></ul>`,
+			selector: "ul#list",
+			want:     true,
+		},
+		{
+			name:     "match '.a.b' selector for <ul class='b c a'>",
+			html:     `<ul class="b c a"></ul>`,
+			selector: ".a.b",
+			want:     true,
+		},
+		{
+			name:     "not match '.a.b' selector for <ul class='a c'>",
+			html:     `<ul class="a c"></ul>`,
+			selector: ".a.b",
+			want:     false,
+		},
+		{
+			name:     "not match '.list' selector for <ul id='list'>",
+			html:     `<ul id="list"></ul>`,
+			selector: ".list",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			n, _ := html.ParseFragment(strings.NewReader(tt.html), &html.Node{
+				Type:     html.ElementNode,
+				DataAtom: atom.Body,
+				Data:     "body",
+			})
+			got, err := Compile(tt.selector)
+			if err != nil {
+				t1.Errorf("Compile() error = %v", err)
+				return
+			}
+			if res := got.Match(n[0]); res != tt.want {
+				t1.Errorf("Match() = %v, want %v for html '%s' with selector %s", res, tt.want, tt.html, tt.selector)
+			}
+		})
+	}
+}
+
+func TestCompile_String(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     string
+	}{
+		{name: "serialize a tag selector keeping its case", selector: "UL", want: "UL"},
+		{name: "serialize a universal selector", selector: "*", want: "*"},
+		{name: "serialize an id selector with escaped digit", selector: `#\31 23`, want: `#\31 23`},
+		{name: "serialize a class selector with escaped characters", selector: `.a\.b\:c`, want: `.a\.b\:c`},
+		{name: "serialize a class selector with escaped whitespace", selector: `.a\20 b`, want: `.a\ b`},
+		{name: "serialize a class selector with a single dash", selector: `.\-`, want: `.\-`},
+		{name: "serialize an attribute selector", selector: `[key]`, want: `[key]`},
+		{name: "serialize an attribute selector with value", selector: `[key~=val]`, want: `[key~="val"]`},
+		{
+			name:     "serialize an attribute selector with escaped value and modifier",
+			selector: `[key='say "hi" \\o/' I]`,
+			want:     `[key="say \"hi\" \\o/" i]`,
+		},
+		{name: "serialize a compound selector", selector: `li.a[x]#b:first-child`, want: `li.a[x]#b:first-child`},
+		{name: "serialize combinators", selector: "ul>li  a+b~c d", want: "ul > li a + b ~ c d"},
+		{name: "serialize a selector list", selector: "h1,h2 ,  .title", want: "h1, h2, .title"},
+		{name: "serialize :nth-child odd", selector: "li:nth-child(odd)", want: "li:nth-child(2n+1)"},
+		{name: "serialize :nth-last-of-type", selector: ":nth-last-of-type(-n + 3)", want: ":nth-last-of-type(-n+3)"},
+		{name: "serialize :nth-last-child", selector: ":nth-last-child(-2)", want: ":nth-last-child(-2)"},
+		{name: "serialize :nth-child with of selector", selector: ":nth-child(2N-1 of .a,.b)", want: ":nth-child(2n-1 of .a, .b)"},
+		{name: "serialize :nth-of-type", selector: ":nth-of-type(n)", want: ":nth-of-type(n)"},
+		{name: "serialize logical pseudo-classes", selector: ":not(a,b):is(c):where(d > e)", want: ":not(a, b):is(c):where(d > e)"},
+		{name: "serialize :has", selector: "li:has(>img,a  span, + p)", want: "li:has(> img, a span, + p)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			got, err := Compile(tt.selector)
+			if err != nil {
+				t1.Errorf("Compile() error = %v", err)
+				return
+			}
+			if res := got.String(); res != tt.want {
+				t1.Errorf("String() = %v, want %v", res, tt.want)
+			}
+
+			again, err := Compile(got.String())
+			if err != nil {
+				t1.Errorf("Compile() error = %v for serialized selector %s", err, got.String())
+				return
+			}
+			if !reflect.DeepEqual(again, got) {
+				t1.Errorf("Compile() got = %v, want %v for serialized selector %s", again, got, got.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package selector
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+type CompoundSelector struct {
+	sels []Sel
+}
+
+func (t CompoundSelector) Match(n *html.Node) bool {
+	for _, sel := range t.sels {
+		if !sel.Match(n) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t CompoundSelector) Specificity() [3]int {
+	var specificity [3]int
+	for _, sel := range t.sels {
+		specificity = addSpecificity(specificity, sel.Specificity())
+	}
+
+	return specificity
+}
+
+func (t CompoundSelector) String() string {
+	var str strings.Builder
+	for _, sel := range t.sels {
+		str.WriteString(sel.String())
+	}
+
+	return str.String()
+}
@@ -0,0 +1,50 @@
+package selector
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+func TestCompoundSelector_Match(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		sels []Sel
+		want bool
+	}{
+		{
+			name: "match element when every selector matches for <li id='first' class='item'>",
+			html: `<li id="first" class="item"></li>`,
+			sels: []Sel{&TagSelector{tag: "li", name: "li", atom: atom.Li}, &ClassSelector{class: "item"}, &IdSelector{id: "first"}},
+			want: true,
+		},
+		{
+			name: "not match element when one selector does not match for <li id='second' class='item'>",
+			html: `<li id="second" class="item"></li>`,
+			sels: []Sel{&TagSelector{tag: "li", name: "li", atom: atom.Li}, &ClassSelector{class: "item"}, &IdSelector{id: "first"}},
+			want: false,
+		},
+		{
+			name: "not match element with other tag for <p id='first' class='item'>",
+			html: `<p id="first" class="item"></p>`,
+			sels: []Sel{&TagSelector{tag: "li", name: "li", atom: atom.Li}, &IdSelector{id: "first"}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			n, _ := html.ParseFragment(strings.NewReader(tt.html), &html.Node{
+				Type:     html.ElementNode,
+				DataAtom: atom.Ul,
+				Data:     "ul",
+			})
+			got := CompoundSelector{sels: tt.sels}
+			if res := got.Match(n[0]); res != tt.want {
+				t1.Errorf("Match() = %v, want %v for html '%s'", res, tt.want, tt.html)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package selector
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// SelectorError describes where and why a selector could not be parsed
+type SelectorError struct {
+	// Offset is the byte position in the selector where the error was found
+	Offset int
+	// Token is the offending input at Offset, empty when the selector ended unexpectedly
+	Token string
+	// Expected lists what the parser would have accepted at Offset
+	Expected []string
+	// Err is the underlying cause, like an invalid number or regular expression
+	Err error
+}
+
+func (e *SelectorError) Error() string {
+	found := "end of selector"
+	if e.Token != "" {
+		found = fmt.Sprintf("'%s'", e.Token)
+	}
+
+	msg := fmt.Sprintf("expected %s, found %s at position %d", strings.Join(e.Expected, " or "), found, e.Offset)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+
+	return msg
+}
+
+func (e *SelectorError) Unwrap() error {
+	return e.Err
+}
+
+// newError returns a SelectorError at the current position taking the next character as the offending token
+func (s *SelParser) newError(expected ...string) *SelectorError {
+	token := ""
+	if s.pos < s.selLen {
+		r, _ := utf8.DecodeRuneInString(s.sel[s.pos:])
+		token = string(r)
+	}
+
+	return s.newErrorAt(s.pos, token, expected...)
+}
+
+// newCauseError returns a SelectorError for the token found at offset caused by err
+func (s *SelParser) newCauseError(err error, offset int, token string, expected ...string) *SelectorError {
+	e := s.newErrorAt(offset, token, expected...)
+	e.Err = err
+
+	return e
+}
+
+// newErrorAt returns a SelectorError for the token found at offset
+func (s *SelParser) newErrorAt(offset int, token string, expected ...string) *SelectorError {
+	return &SelectorError{
+		Offset:   offset,
+		Token:    token,
+		Expected: expected,
+	}
+}
@@ -0,0 +1,119 @@
+package selector
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestCompile_SelectorError(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		offset   int
+		token    string
+	}{
+		{name: "empty selector", selector: "", offset: 0, token: ""},
+		{name: "unknown character", selector: "!ul", offset: 0, token: "!"},
+		{name: "trailing unknown character", selector: "ul#a!b", offset: 4, token: "!"},
+		{name: "id without name", selector: "#", offset: 1, token: ""},
+		{name: "class without name", selector: "a. b", offset: 2, token: " "},
+		{name: "class starting with digit", selector: ".1a", offset: 1, token: "1"},
+		{name: "id starting with digit", selector: "#1a", offset: 1, token: "1"},
+		{name: "id starting with '-' and digit", selector: "#-1", offset: 1, token: "-"},
+		{name: "id of a single '-'", selector: "li#-", offset: 3, token: "-"},
+		{name: "type selector starting with digit", selector: "1a", offset: 0, token: "1"},
+		{name: "type selector starting with '-' and digit", selector: "-1", offset: 0, token: "-"},
+		{name: "type selector of a single '-'", selector: "-", offset: 0, token: "-"},
+		{name: "type selector after combinator starting with digit", selector: "p > 2n", offset: 4, token: "2"},
+		{name: "attribute operation at end", selector: "[key~", offset: 5, token: ""},
+		{name: "attribute bad operation", selector: "[key~x]", offset: 5, token: "x"},
+		{name: "attribute without name", selector: "[=a]", offset: 1, token: "="},
+		{name: "attribute without value", selector: "[a=]", offset: 3, token: "]"},
+		{name: "unclosed attribute", selector: "[a=b", offset: 4, token: ""},
+		{name: "unterminated string", selector: `[a="b`, offset: 5, token: ""},
+		{name: "new line in string", selector: "[a=\"b\nc\"]", offset: 5, token: "\n"},
+		{name: "escaped new line", selector: "a\\\n", offset: 1, token: "\\"},
+		{name: "combinator at end", selector: "ul >", offset: 4, token: ""},
+		{name: "type selector after class", selector: ".a*", offset: 2, token: "*"},
+		{name: "unknown pseudo-class", selector: "a:unknown", offset: 1, token: ":unknown"},
+		{name: "unsupported :scope pseudo-class", selector: ":scope > li", offset: 0, token: ":scope"},
+		{name: "unknown functional pseudo-class", selector: "a:unknown(b)", offset: 1, token: ":unknown("},
+		{name: "extension without extensions enabled", selector: ":contains(a)", offset: 0, token: ":contains("},
+		{name: "pseudo-element", selector: "p::before", offset: 2, token: ":"},
+		{name: "bad An+B", selector: ":nth-child(x)", offset: 11, token: "x"},
+		{name: "unclosed pseudo-class arguments", selector: ":not(a", offset: 6, token: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			_, err := Compile(tt.selector)
+			var selErr *SelectorError
+			if !errors.As(err, &selErr) {
+				t1.Errorf("Compile() error = %v, want *SelectorError", err)
+				return
+			}
+			if selErr.Offset != tt.offset || selErr.Token != tt.token {
+				t1.Errorf("Compile() error at %d with token %q, want at %d with token %q", selErr.Offset, selErr.Token, tt.offset, tt.token)
+			}
+			if len(selErr.Expected) == 0 {
+				t1.Errorf("Compile() error without expected set")
+			}
+		})
+	}
+}
+
+func TestSelectorError_Error(t *testing.T) {
+	cause := &strconv.NumError{Func: "Atoi", Num: "99999999999999999999", Err: strconv.ErrRange}
+	tests := []struct {
+		name string
+		err  *SelectorError
+		want string
+	}{
+		{
+			name: "error with token",
+			err:  &SelectorError{Offset: 3, Token: "!", Expected: []string{"id name"}},
+			want: "expected id name, found '!' at position 3",
+		},
+		{
+			name: "error at end of selector with multiple expected",
+			err:  &SelectorError{Offset: 4, Expected: []string{"a", "b"}},
+			want: "expected a or b, found end of selector at position 4",
+		},
+		{
+			name: "error with cause",
+			err:  &SelectorError{Offset: 0, Token: "99999999999999999999", Expected: []string{"integer"}, Err: cause},
+			want: "expected integer, found '99999999999999999999' at position 0: " + cause.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t1.Errorf("Error() = %v, want %v", got, tt.want)
+			}
+			if got := errors.Unwrap(tt.err); !reflect.DeepEqual(got, tt.err.Err) {
+				t1.Errorf("Unwrap() = %v, want %v", got, tt.err.Err)
+			}
+		})
+	}
+}
+
+func TestParser_NoPanic(t *testing.T) {
+	inputs := []string{"", "#", ".", "[", "[a", "[a~", "[a=", `[a="`, "\\", `a\`, ":", "::", ":nth-child(", ":has(", "*", "\r"}
+	for _, input := range inputs {
+		t.Run(strconv.Quote(input), func(t1 *testing.T) {
+			defer func() {
+				if r := recover(); r != nil {
+					t1.Errorf("Parse() panic = %v for %q", r, input)
+				}
+			}()
+
+			for _, p := range []Parser{
+				NewSelectorParser(input), NewTagParser(input), NewIdParser(input), NewClassParser(input),
+				NewAttributeParser(input), NewUniversalParser(input), NewPseudoParser(input),
+			} {
+				_, _ = p.Parse()
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package selector
+
+import (
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// readOnlyInputTypes are the input types where the readonly attribute applies
+// as defined in https://html.spec.whatwg.org/multipage/input.html#concept-input-apply
+var readOnlyInputTypes = map[string]bool{
+	"text": true, "search": true, "url": true, "tel": true, "email": true, "password": true, "date": true,
+	"month": true, "week": true, "time": true, "datetime-local": true, "number": true,
+}
+
+// placeholderInputTypes are the input types where the placeholder attribute applies
+var placeholderInputTypes = map[string]bool{
+	"text": true, "search": true, "url": true, "tel": true, "email": true, "password": true, "number": true,
+}
+
+// inputTypes are the keywords of the type attribute of input elements, any other value is the text state
+// as defined in https://html.spec.whatwg.org/multipage/input.html#attr-input-type
+var inputTypes = map[string]bool{
+	"hidden": true, "text": true, "search": true, "tel": true, "url": true, "email": true, "password": true,
+	"date": true, "month": true, "week": true, "time": true, "datetime-local": true, "number": true, "range": true,
+	"color": true, "checkbox": true, "radio": true, "file": true, "submit": true, "image": true, "reset": true,
+	"button": true,
+}
+
+// notRequiredInputTypes are the input types where the required attribute does not apply
+var notRequiredInputTypes = map[string]bool{
+	"hidden": true, "range": true, "color": true, "submit": true, "image": true, "reset": true, "button": true,
+}
+
+// isChecked checks if n is a checked checkbox or radio button, or a selected option
+// as defined in https://html.spec.whatwg.org/multipage/semantics-other.html#selector-checked
+func isChecked(n *html.Node) bool {
+	switch {
+	case isHTMLElement(n, atom.Input):
+		inputType := getInputType(n)
+		return (inputType == "checkbox" || inputType == "radio") && hasAttribute(n, "checked")
+	case isHTMLElement(n, atom.Option):
+		return hasAttribute(n, "selected")
+	}
+
+	return false
+}
+
+// isDisabled checks if n is an actually disabled form element
+// as defined in https://html.spec.whatwg.org/multipage/semantics-other.html#concept-element-disabled
+func isDisabled(n *html.Node) bool {
+	switch {
+	case isHTMLElement(n, atom.Button, atom.Input, atom.Select, atom.Textarea):
+		return hasAttribute(n, "disabled") || isInDisabledFieldset(n)
+	case isHTMLElement(n, atom.Fieldset):
+		return hasAttribute(n, "disabled") || isInDisabledFieldset(n)
+	case isHTMLElement(n, atom.Optgroup):
+		return hasAttribute(n, "disabled")
+	case isHTMLElement(n, atom.Option):
+		return hasAttribute(n, "disabled") || n.Parent != nil && isHTMLElement(n.Parent, atom.Optgroup) && hasAttribute(n.Parent, "disabled")
+	}
+
+	return false
+}
+
+// isEnabled checks if n is a form element that is not disabled
+// as defined in https://html.spec.whatwg.org/multipage/semantics-other.html#selector-enabled
+func isEnabled(n *html.Node) bool {
+	return isHTMLElement(n, atom.Button, atom.Input, atom.Select, atom.Textarea, atom.Fieldset, atom.Optgroup, atom.Option) &&
+		!isDisabled(n)
+}
+
+// isInDisabledFieldset checks if n is a descendant of a disabled fieldset, except inside its first legend
+// as defined in https://html.spec.whatwg.org/multipage/form-control-infrastructure.html#concept-fe-disabled
+func isInDisabledFieldset(n *html.Node) bool {
+	for c, p := n, n.Parent; p != nil; c, p = p, p.Parent {
+		if !isHTMLElement(p, atom.Fieldset) || !hasAttribute(p, "disabled") {
+			continue
+		}
+
+		legend := p.FirstChild
+		for legend != nil && !isHTMLElement(legend, atom.Legend) {
+			legend = nextElementSibling(legend)
+		}
+		if c != legend {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isRequired checks if n is a form element with the required attribute
+// as defined in https://html.spec.whatwg.org/multipage/semantics-other.html#selector-required
+func isRequired(n *html.Node) bool {
+	switch {
+	case isHTMLElement(n, atom.Input):
+		return hasAttribute(n, "required") && !notRequiredInputTypes[getInputType(n)]
+	case isHTMLElement(n, atom.Select, atom.Textarea):
+		return hasAttribute(n, "required")
+	}
+
+	return false
+}
+
+// isOptional checks if n is a form element that can be required but it is not
+// as defined in https://html.spec.whatwg.org/multipage/semantics-other.html#selector-optional
+func isOptional(n *html.Node) bool {
+	return isHTMLElement(n, atom.Input, atom.Select, atom.Textarea) && !isRequired(n)
+}
+
+// isReadWrite checks if n is a mutable text control or an editable element
+// as defined in https://html.spec.whatwg.org/multipage/semantics-other.html#selector-read-write
+func isReadWrite(n *html.Node) bool {
+	switch {
+	case isHTMLElement(n, atom.Input):
+		return readOnlyInputTypes[getInputType(n)] && !hasAttribute(n, "readonly") && !isDisabled(n)
+	case isHTMLElement(n, atom.Textarea):
+		return !hasAttribute(n, "readonly") && !isDisabled(n)
+	}
+
+	return isEditable(n)
+}
+
+// isEditable checks if n is editable by the contenteditable attribute of itself or of the closest ancestor defining it
+// as defined in https://html.spec.whatwg.org/multipage/interaction.html#attr-contenteditable
+func isEditable(n *html.Node) bool {
+	for p := n; p != nil && html.ElementNode == p.Type; p = p.Parent {
+		if val, ok := getAttribute(p, "contenteditable"); ok {
+			switch toASCIILower(val) {
+			case "", "true", "plaintext-only":
+				return true
+			case "false":
+				return false
+			}
+		}
+	}
+
+	return false
+}
+
+// isPlaceholderShown checks if n is a text control showing its placeholder because its value is empty
+// as defined in https://html.spec.whatwg.org/multipage/semantics-other.html#selector-placeholder-shown
+func isPlaceholderShown(n *html.Node) bool {
+	if !hasAttribute(n, "placeholder") {
+		return false
+	}
+
+	switch {
+	case isHTMLElement(n, atom.Input):
+		val, _ := getAttribute(n, "value")
+		return placeholderInputTypes[getInputType(n)] && val == ""
+	case isHTMLElement(n, atom.Textarea):
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if html.TextNode == c.Type && c.Data != "" {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+// isLink checks if n is a hyperlink source (<a> or <area> with href attribute)
+// as defined in https://html.spec.whatwg.org/multipage/semantics-other.html#selector-link
+func isLink(n *html.Node) bool {
+	return isHTMLElement(n, atom.A, atom.Area) && hasAttribute(n, "href")
+}
+
+// getInputType returns the normalized type of an input element, text when missing or unknown
+func getInputType(n *html.Node) string {
+	inputType, _ := getAttribute(n, "type")
+	if inputType = toASCIILower(inputType); !inputTypes[inputType] {
+		return "text"
+	}
+
+	return inputType
+}
+
+// isHTMLElement checks if n is an element of the html namespace with one of the given tags
+func isHTMLElement(n *html.Node, tags ...atom.Atom) bool {
+	if html.ElementNode != n.Type || "" != n.Namespace {
+		return false
+	}
+
+	for _, tag := range tags {
+		if n.DataAtom == tag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,71 @@
+package selector
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestPseudoSelector_MatchForm(t *testing.T) {
+	doc := `<html><body>
+<form id="form">
+	<input id="text" required placeholder="Name">
+	<input id="filled" type="TEXT" placeholder="Name" value="John" readonly>
+	<input id="checkbox" type="checkbox" checked required>
+	<input id="radio" type="radio">
+	<input id="bogus" type="bogus" placeholder="p">
+	<input id="hidden" type="hidden" required>
+	<select id="select" required>
+		<optgroup id="group" disabled><option id="grouped">A</option></optgroup>
+		<option id="selected" selected>B</option>
+	</select>
+	<textarea id="textarea" placeholder="Comment"></textarea>
+	<fieldset id="fieldset" disabled>
+		<legend id="legend"><button id="legend-button"></button></legend>
+		<button id="button"></button>
+	</fieldset>
+	<input id="disabled" disabled>
+</form>
+<div id="editable" contenteditable><p id="editable-p" contenteditable="false"></p></div>
+<a id="link" href="/"></a><a id="anchor"></a><map><area id="area" href="/"></map>
+</body></html>`
+	tests := []struct {
+		name     string
+		selector string
+		want     []string
+	}{
+		{name: "match :checked", selector: ":checked", want: []string{"checkbox", "selected"}},
+		{
+			name:     "match :disabled",
+			selector: ":disabled",
+			want:     []string{"group", "grouped", "fieldset", "button", "disabled"},
+		},
+		{
+			name:     "match :enabled",
+			selector: ":enabled",
+			want:     []string{"text", "filled", "checkbox", "radio", "bogus", "hidden", "select", "selected", "textarea", "legend-button"},
+		},
+		{name: "match :required", selector: ":required", want: []string{"text", "checkbox", "select"}},
+		{name: "match :optional", selector: ":optional", want: []string{"filled", "radio", "bogus", "hidden", "textarea", "disabled"}},
+		{name: "match :read-write", selector: ":read-write", want: []string{"text", "bogus", "textarea", "editable"}},
+		{name: "match :read-only inputs", selector: "input:read-only", want: []string{"filled", "checkbox", "radio", "hidden", "disabled"}},
+		{name: "match :placeholder-shown", selector: ":placeholder-shown", want: []string{"text", "bogus", "textarea"}},
+		{name: "match :link", selector: ":link", want: []string{"link", "area"}},
+		{name: "match :any-link", selector: ":any-link", want: []string{"link", "area"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			root, _ := html.Parse(strings.NewReader(doc))
+			s, err := Compile(tt.selector)
+			if err != nil {
+				t1.Errorf("Compile() error = %v", err)
+				return
+			}
+			if got := ids(QueryAll(root, s)); !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
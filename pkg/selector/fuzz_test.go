@@ -0,0 +1,126 @@
+package selector
+
+import (
+	"testing"
+	"time"
+)
+
+// fuzzSeeds are the inputs every fuzz target starts from, including the ones that used to hang or panic
+var fuzzSeeds = []string{
+	"ul", "ul#list > li", `li.pretty-element-list[data-x="1"]#first`, "h1, h2, .title", "td.label + td ~ td",
+	`[title="Hello world" i]`, `[data-x='a.b']`, `[a~=b s]`, ":nth-child(2n+1 of li.item)", ":nth-last-of-type(-n + 3)",
+	":not(.a, b):is(c):where(d > e)", "li:has(> img, + p)", ":root > li", ":contains(\"text\")", ":matches(/a\\/b/i)",
+	`\000073ection`, `\73 ection`, `.a\.b\:c`, `#\31 23`, "#a!b", "[key~", "#", "\\", "a\\", "[a=\"b", "::before",
+	"sect\r", "li:nth-child(", "¡ul", "é.ü#ß",
+}
+
+// parseWithTimeout runs parse failing the test when it does not finish in time, so infinite loops are reported
+func parseWithTimeout(t *testing.T, sel string, parse func() (Sel, error)) (Sel, error) {
+	type result struct {
+		sel Sel
+		err error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		s, err := parse()
+		done <- result{sel: s, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.sel, r.err
+	case <-time.After(time.Second):
+		t.Fatalf("Parse() did not finish for %q", sel)
+		return nil, nil
+	}
+}
+
+// checkRoundTrip checks that compiling the text of s gives a selector with the same text
+func checkRoundTrip(t *testing.T, sel string, s Sel) {
+	c := Compiler{Extensions: true}
+	again, err := c.Compile(s.String())
+	if err != nil {
+		t.Fatalf("Compile() error = %v for %q serialized from %q", err, s.String(), sel)
+	}
+	if again.String() != s.String() {
+		t.Fatalf("String() = %q, want %q serialized from %q", again.String(), s.String(), sel)
+	}
+}
+
+// fuzzParser checks that the parser built for the input never hangs nor panics and that its result round-trips
+func fuzzParser(f *testing.F, newParser func(sel string) Parser) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, sel string) {
+		s, err := parseWithTimeout(t, sel, newParser(sel).Parse)
+		if err != nil {
+			if _, ok := err.(*SelectorError); !ok {
+				t.Fatalf("Parse() error = %T, want *SelectorError", err)
+			}
+			return
+		}
+		checkRoundTrip(t, sel, s)
+	})
+}
+
+func FuzzCompile(f *testing.F) {
+	fuzzParser(f, func(sel string) Parser {
+		p := NewSelectorParser(sel)
+		p.extensions = true
+		return p
+	})
+}
+
+func FuzzTagParser(f *testing.F) {
+	fuzzParser(f, func(sel string) Parser { return NewTagParser(sel) })
+}
+
+func FuzzIdParser(f *testing.F) {
+	fuzzParser(f, func(sel string) Parser { return NewIdParser(sel) })
+}
+
+func FuzzClassParser(f *testing.F) {
+	fuzzParser(f, func(sel string) Parser { return NewClassParser(sel) })
+}
+
+func FuzzAttributeParser(f *testing.F) {
+	fuzzParser(f, func(sel string) Parser { return NewAttributeParser(sel) })
+}
+
+func FuzzPseudoParser(f *testing.F) {
+	fuzzParser(f, func(sel string) Parser {
+		p := NewPseudoParser(sel)
+		p.extensions = true
+		return p
+	})
+}
+
+func FuzzParseEscape(f *testing.F) {
+	for _, seed := range []string{`\000073`, `\73 `, `\U+0073`, `\.`, `\`, `\110000`, `\0`, "\\\n", `\é`, `\d800 `} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, sel string) {
+		p := &SelParser{sel: sel, selLen: len(sel)}
+		if sel == "" || sel[0] != '\\' {
+			p = &SelParser{sel: `\` + sel, selLen: len(sel) + 1}
+		}
+
+		c, err := p.parseEscape()
+		if err != nil {
+			return
+		}
+		if p.pos > p.selLen {
+			t.Fatalf("parseEscape() position = %d, want at most %d for %q", p.pos, p.selLen, p.sel)
+		}
+		if p.pos == 1 && p.selLen > 1 {
+			t.Fatalf("parseEscape() did not consume the escaped character of %q", p.sel)
+		}
+		if c == "" {
+			t.Fatalf("parseEscape() = %q, want a character for %q", c, p.sel)
+		}
+	})
+}
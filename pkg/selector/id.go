@@ -1,8 +1,6 @@
 package selector
 
 import (
-	"fmt"
-
 	"golang.org/x/net/html"
 )
 
@@ -14,8 +12,6 @@ func (t IdSelector) Match(n *html.Node) bool {
 	if html.ElementNode == n.Type {
 		for _, attr := range n.Attr {
 			if "id" == attr.Key && t.id == attr.Val {
-				fmt.Println(attr.Val, t.id)
-
 				return true
 			}
 		}
@@ -24,13 +20,21 @@ func (t IdSelector) Match(n *html.Node) bool {
 	return false
 }
 
+func (t IdSelector) Specificity() [3]int {
+	return [3]int{1, 0, 0}
+}
+
+func (t IdSelector) String() string {
+	return "#" + serializeIdentifier(t.id)
+}
+
 type IdParser struct {
-	SelParser
+	*SelParser
 }
 
 func NewIdParser(sel string) *IdParser {
 	return &IdParser{
-		SelParser{
+		&SelParser{
 			sel:    sel,
 			selLen: len(sel),
 		},
@@ -38,37 +42,17 @@ func NewIdParser(sel string) *IdParser {
 }
 
 func (i IdParser) Parse() (Sel, error) {
-	if i.sel[i.pos] != '#' {
-		return nil, fmt.Errorf("expected key selector (#key), found '%c'", i.sel[i.pos])
+	if i.pos >= i.selLen || i.sel[i.pos] != '#' {
+		return nil, i.newError("id selector (#id)")
 	}
 	i.pos++
+	if !i.isIdentifierStart(i.pos) { // the id must be an identifier, so it can not start with a digit
+		return nil, i.newError("id name")
+	}
 
-	id := ""
-	for i.pos < i.selLen {
-		char := i.sel[i.pos]
-
-		switch {
-		case i.isValidTagNameChar(char): // get current "i" if is a valid name character
-			id += string(char)
-			i.pos++
-			break
-		case char == '\\': // sel have an escaped element https://drafts.csswg.org/css-syntax-3/#escaping
-			c, err := i.parseEscape()
-			if err != nil {
-				return nil, err
-			}
-			id += c
-			break
-		case char == '\r':
-			i.pos++
-			if i.sel[i.pos] == '\n' {
-				i.pos++ // for end of lines \r\n
-			}
-			break
-		case char == ' ' || char == '\n' || char == '\t':
-			i.pos++
-			break
-		}
+	id, err := i.parseIdentifier()
+	if err != nil {
+		return nil, err
 	}
 
 	return &IdSelector{
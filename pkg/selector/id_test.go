@@ -41,25 +41,60 @@ func TestIdParser_Parse(t *testing.T) {
 			want:     &IdSelector{id: "testid"},
 		},
 		{
-			name:     "parse key sel with escaped UNICODE element '\\U+000069' (s)",
-			selector: `#test\U+000069d`,
-			want:     &IdSelector{id: "testid"},
+			name:     "parse key sel starting with '-'",
+			selector: `#-a`,
+			want:     &IdSelector{id: "-a"},
 		},
 		{
-			name:     "parse key sel with multiple escaped UNICODE element '\\U+000065' (s) and '\\U+000069' (i)",
-			selector: `#t\U+000065st\U+000069d`,
-			want:     &IdSelector{id: "testid"},
+			name:     "parse key sel starting with '--' and digit",
+			selector: `#--1`,
+			want:     &IdSelector{id: "--1"},
 		},
 		{
-			name:     "parse key sel with escaped UNICODE element with 4 digits and whitespace '\\U+0073' (s)",
+			name:     "parse key sel starting with escaped digit",
+			selector: `#\31 a`,
+			want:     &IdSelector{id: "1a"},
+		},
+		{
+			name:     "parse key sel with '\\U+' escaping only the letter U",
 			selector: `#test\U+0069 d`,
-			want:     &IdSelector{id: "testid"},
+			want:     &IdSelector{id: "testU"},
 		},
 		{
-			name:     "parse key sel with multiple escaped UNICODE element with 4 digits and whitespace '\\U+0073' (s) and '\\U+0069' (i)",
-			selector: `#t\U+0065 st\U+0069 d`,
+			name:     "parse key sel with escaped non-hex characters '\\.' and '\\:'",
+			selector: `#a\.b\:c`,
+			want:     &IdSelector{id: "a.b:c"},
+		},
+		{
+			name:     "parse key sel with escaped element and \\r\\n as its whitespace",
+			selector: "#test\\69\r\nd",
 			want:     &IdSelector{id: "testid"},
 		},
+		{
+			name:     "parse key sel with escaped zero as replacement character",
+			selector: `#test\0 d`,
+			want:     &IdSelector{id: "test\ufffdd"},
+		},
+		{
+			name:     "parse key sel with escaped surrogate as replacement character",
+			selector: `#test\d800 d`,
+			want:     &IdSelector{id: "test\ufffdd"},
+		},
+		{
+			name:     "parse key sel with escaped code point out of range as replacement character",
+			selector: `#test\110000d`,
+			want:     &IdSelector{id: "test\ufffdd"},
+		},
+		{
+			name:     "parse key sel with escape at end as replacement character",
+			selector: `#test\`,
+			want:     &IdSelector{id: "test\ufffd"},
+		},
+		{
+			name:     "parse key sel ending at escaped new line",
+			selector: "#test\\\nd",
+			want:     &IdSelector{id: "test"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(
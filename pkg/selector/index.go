@@ -0,0 +1,160 @@
+package selector
+
+import (
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Index maps the ids, classes and tags of the descendant elements of a root to the elements in document order,
+// so queries start from the elements that can match instead of walking the whole tree.
+// The index is not updated when the tree changes, it must be built again
+type Index struct {
+	root    *html.Node
+	order   map[*html.Node]int
+	ids     map[string][]*html.Node
+	classes map[string][]*html.Node
+	tags    map[string][]*html.Node
+}
+
+// NewIndex walks once the descendant elements of root to build its Index
+func NewIndex(root *html.Node) *Index {
+	ix := &Index{
+		root:    root,
+		order:   map[*html.Node]int{},
+		ids:     map[string][]*html.Node{},
+		classes: map[string][]*html.Node{},
+		tags:    map[string][]*html.Node{},
+	}
+
+	walk(root, nil, func(n *html.Node) bool {
+		ix.order[n] = len(ix.order)
+
+		ix.tags[n.Data] = append(ix.tags[n.Data], n)
+
+		for _, attr := range n.Attr {
+			switch attr.Key {
+			case "id":
+				ix.ids[attr.Val] = append(ix.ids[attr.Val], n)
+			case "class":
+				for _, class := range strings.FieldsFunc(attr.Val, isASCIIWhitespace) {
+					if nodes := ix.classes[class]; len(nodes) == 0 || nodes[len(nodes)-1] != n {
+						ix.classes[class] = append(nodes, n)
+					}
+				}
+			}
+		}
+
+		return true
+	})
+
+	return ix
+}
+
+// QueryAll returns the indexed elements matching s in document order, like the QueryAll function on the root
+func (ix *Index) QueryAll(s Sel) []*html.Node {
+	candidates, ok := ix.candidates(s)
+	if !ok {
+		return QueryAll(ix.root, s)
+	}
+
+	return Filter(candidates, s)
+}
+
+// QueryFirst returns the first indexed element matching s in document order, nil if none matches
+func (ix *Index) QueryFirst(s Sel) *html.Node {
+	candidates, ok := ix.candidates(s)
+	if !ok {
+		return QueryFirst(ix.root, s)
+	}
+
+	for _, n := range candidates {
+		if s.Match(n) {
+			return n
+		}
+	}
+
+	return nil
+}
+
+// candidates returns in document order the elements that can match s, found with the id, class or type selectors
+// of the rightmost compound selector of every selector of the list, false when any of them has none
+func (ix *Index) candidates(s Sel) ([]*html.Node, bool) {
+	l, ok := s.(*SelectorList)
+	if !ok {
+		return ix.compoundCandidates(s)
+	}
+
+	var candidates []*html.Node
+	seen := map[*html.Node]bool{}
+	for _, sel := range l.sels {
+		nodes, ok := ix.compoundCandidates(sel)
+		if !ok {
+			return nil, false
+		}
+
+		for _, n := range nodes {
+			if !seen[n] {
+				seen[n] = true
+				candidates = append(candidates, n)
+			}
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return ix.order[candidates[i]] < ix.order[candidates[j]]
+	})
+
+	return candidates, true
+}
+
+// compoundCandidates returns the shortest list of elements found with the id, class or type selectors
+// of the rightmost compound selector of s
+func (ix *Index) compoundCandidates(s Sel) ([]*html.Node, bool) {
+	if c, ok := s.(*CombinatorSelector); ok {
+		s = c.right
+	}
+
+	sels := []Sel{s}
+	if c, ok := s.(*CompoundSelector); ok {
+		sels = c.sels
+	}
+
+	var candidates []*html.Node
+	found := false
+	for _, sel := range sels {
+		var nodes []*html.Node
+		switch t := sel.(type) {
+		case *IdSelector:
+			nodes = ix.ids[t.id]
+		case *ClassSelector:
+			nodes = ix.classes[t.class]
+		case *TagSelector:
+			nodes = ix.tagCandidates(t)
+		default:
+			continue
+		}
+
+		if !found || len(nodes) < len(candidates) {
+			candidates, found = nodes, true
+		}
+	}
+
+	return candidates, found
+}
+
+// tagCandidates returns in document order the elements named as t matches them, html elements by the lower case tag
+// and foreign elements by the name as written
+func (ix *Index) tagCandidates(t *TagSelector) []*html.Node {
+	if t.tag == t.name {
+		return ix.tags[t.tag]
+	}
+
+	nodes := make([]*html.Node, 0, len(ix.tags[t.tag])+len(ix.tags[t.name]))
+	nodes = append(append(nodes, ix.tags[t.tag]...), ix.tags[t.name]...)
+	sort.Slice(nodes, func(i, j int) bool {
+		return ix.order[nodes[i]] < ix.order[nodes[j]]
+	})
+
+	return nodes
+}
@@ -0,0 +1,120 @@
+package selector
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestIndex_QueryAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     []string
+	}{
+		{name: "query by id", selector: "#second", want: []string{"second"}},
+		{name: "query by class", selector: ".item", want: []string{"first", "second"}},
+		{name: "query by tag", selector: "li", want: []string{"first", "second"}},
+		{name: "query by compound selector", selector: "li.item#second", want: []string{"second"}},
+		{name: "query by rightmost compound of combinators", selector: "ul#list > .item", want: []string{"first", "second"}},
+		{name: "query selector list in document order", selector: "h1, #second, .title", want: []string{"h2", "second", "h1"}},
+		{name: "query selector list without duplicates", selector: "li, .item", want: []string{"first", "second"}},
+		{name: "query without indexed selector", selector: ":first-child", want: []string{"h2", "first"}},
+		{name: "query list with a branch without indexed selector", selector: "h1, [id=list]", want: []string{"list", "h1"}},
+		{name: "query nothing for unknown id", selector: "#unknown", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			root, _ := html.Parse(strings.NewReader(queryDoc))
+			ix := NewIndex(root)
+			s, err := Compile(tt.selector)
+			if err != nil {
+				t1.Errorf("Compile() error = %v", err)
+				return
+			}
+			if got := ids(ix.QueryAll(s)); !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("QueryAll() = %v, want %v", got, tt.want)
+			}
+			if got, want := ix.QueryFirst(s), QueryFirst(root, s); got != want {
+				t1.Errorf("QueryFirst() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestIndex_candidates(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     []string
+		wantOk   bool
+	}{
+		{name: "id candidates", selector: "#first", want: []string{"first"}, wantOk: true},
+		{name: "shortest candidates of compound", selector: "li.item#first", want: []string{"first"}, wantOk: true},
+		{name: "candidates of rightmost compound", selector: "#list > li", want: []string{"first", "second"}, wantOk: true},
+		{name: "no candidates for universal selector", selector: "*", want: nil, wantOk: false},
+		{name: "no candidates for attribute selector", selector: "li [id]", want: nil, wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			root, _ := html.Parse(strings.NewReader(queryDoc))
+			s, err := Compile(tt.selector)
+			if err != nil {
+				t1.Errorf("Compile() error = %v", err)
+				return
+			}
+			got, ok := NewIndex(root).candidates(s)
+			if ok != tt.wantOk || !reflect.DeepEqual(ids(got), tt.want) {
+				t1.Errorf("candidates() = %v, %v, want %v, %v", ids(got), ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestIndex_QueryAll_Namespaces(t *testing.T) {
+	root, _ := html.Parse(strings.NewReader(namespaceDoc))
+	ix := NewIndex(root)
+	selectors := []string{"linearGradient", "lineargradient", "svg|foreignObject", "svg|foreignobject", "a, rect"}
+	for _, selector := range selectors {
+		t.Run(selector, func(t1 *testing.T) {
+			s, err := Compiler{Namespaces: testNamespaces}.Compile(selector)
+			if err != nil {
+				t1.Errorf("Compile() error = %v", err)
+				return
+			}
+			if got, want := ids(ix.QueryAll(s)), ids(QueryAll(root, s)); !reflect.DeepEqual(got, want) {
+				t1.Errorf("QueryAll() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestIndex_QueryAll_DeepDocument(t *testing.T) {
+	root, _ := html.Parse(strings.NewReader(deepDoc(60)))
+	ix := NewIndex(root)
+	selectors := []string{"div .a span", "#span-30-3", "p.sibling > a.a", ".level59 span, #span-1-1", "svg|*, span"}
+	for _, selector := range selectors {
+		t.Run(selector, func(t1 *testing.T) {
+			s, err := Compiler{Namespaces: map[string]string{"svg": "http://www.w3.org/2000/svg"}}.Compile(selector)
+			if err != nil {
+				t1.Errorf("Compile() error = %v", err)
+				return
+			}
+			if got, want := ix.QueryAll(s), QueryAll(root, s); !reflect.DeepEqual(got, want) {
+				t1.Errorf("QueryAll() found %d elements, want %d", len(got), len(want))
+			}
+		})
+	}
+}
+
+func BenchmarkIndex_QueryAll(b *testing.B) {
+	root, _ := html.Parse(strings.NewReader(deepDoc(60)))
+	ix := NewIndex(root)
+	s, _ := Compile("div .a span#span-30-3")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ix.QueryAll(s)
+	}
+}
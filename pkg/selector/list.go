@@ -0,0 +1,40 @@
+package selector
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+type SelectorList struct {
+	sels []Sel
+}
+
+func (t SelectorList) Match(n *html.Node) bool {
+	for _, sel := range t.sels {
+		if sel.Match(n) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Specificity returns the specificity of the most specific selector of the list
+func (t SelectorList) Specificity() [3]int {
+	return maxSpecificity(t.sels)
+}
+
+func (t SelectorList) String() string {
+	return joinSelectors(t.sels)
+}
+
+// joinSelectors returns the text of sels separated by commas
+func joinSelectors(sels []Sel) string {
+	strs := make([]string, len(sels))
+	for i, sel := range sels {
+		strs[i] = sel.String()
+	}
+
+	return strings.Join(strs, ", ")
+}
@@ -0,0 +1,50 @@
+package selector
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+func TestSelectorList_Match(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		sels []Sel
+		want bool
+	}{
+		{
+			name: "match element when first selector matches for <h1>",
+			html: `<h1></h1>`,
+			sels: []Sel{&TagSelector{tag: "h1", name: "h1", atom: atom.H1}, &TagSelector{tag: "h2", name: "h2", atom: atom.H2}, &ClassSelector{class: "title"}},
+			want: true,
+		},
+		{
+			name: "match element when last selector matches for <p class='title'>",
+			html: `<p class="title"></p>`,
+			sels: []Sel{&TagSelector{tag: "h1", name: "h1", atom: atom.H1}, &TagSelector{tag: "h2", name: "h2", atom: atom.H2}, &ClassSelector{class: "title"}},
+			want: true,
+		},
+		{
+			name: "not match element when no selector matches for <p>",
+			html: `<p></p>`,
+			sels: []Sel{&TagSelector{tag: "h1", name: "h1", atom: atom.H1}, &TagSelector{tag: "h2", name: "h2", atom: atom.H2}, &ClassSelector{class: "title"}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			n, _ := html.ParseFragment(strings.NewReader(tt.html), &html.Node{
+				Type:     html.ElementNode,
+				DataAtom: atom.Body,
+				Data:     "body",
+			})
+			got := SelectorList{sels: tt.sels}
+			if res := got.Match(n[0]); res != tt.want {
+				t1.Errorf("Match() = %v, want %v for html '%s'", res, tt.want, tt.html)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package selector
+
+import (
+	"golang.org/x/net/html"
+)
+
+type LogicalSelector struct {
+	name string
+	sel  Sel
+}
+
+func (t LogicalSelector) Match(n *html.Node) bool {
+	if html.ElementNode != n.Type {
+		return false
+	}
+
+	switch t.name {
+	case "not": // https://drafts.csswg.org/selectors-4/#negation
+		return !t.sel.Match(n)
+	case "is", "where": // https://drafts.csswg.org/selectors-4/#matches and https://drafts.csswg.org/selectors-4/#zero-matches
+		return t.sel.Match(n)
+	}
+
+	return false
+}
+
+// Specificity returns zero for :where() and the specificity of the most specific argument for :is() and :not()
+func (t LogicalSelector) Specificity() [3]int {
+	if t.name == "where" {
+		return [3]int{0, 0, 0}
+	}
+
+	return t.sel.Specificity()
+}
+
+func (t LogicalSelector) String() string {
+	return ":" + t.name + "(" + t.sel.String() + ")"
+}
+
+// HasSelector matches elements anchoring at least one of its relative selectors
+// as defined in https://drafts.csswg.org/selectors-4/#relational
+type HasSelector struct {
+	sels []Sel
+}
+
+func (t HasSelector) Match(n *html.Node) bool {
+	if html.ElementNode != n.Type {
+		return false
+	}
+
+	for _, sel := range t.sels {
+		found := false
+		visit := func(c *html.Node) bool {
+			found = matchAnchored(sel, c, n)
+			return !found
+		}
+
+		switch leadingCombinator(sel) {
+		case '+', '~': // only following siblings and their descendants can be reached
+			for s := nextElementSibling(n); s != nil && !found; s = nextElementSibling(s) {
+				if visit(s) {
+					walk(s, nil, visit)
+				}
+			}
+		default:
+			walk(n, nil, visit)
+		}
+
+		if found {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Specificity returns the specificity of the most specific relative selector
+func (t HasSelector) Specificity() [3]int {
+	return maxSpecificity(t.sels)
+}
+
+func (t HasSelector) String() string {
+	return ":has(" + joinSelectors(t.sels) + ")"
+}
+
+// leadingCombinator returns the combinator relating a relative selector to its anchor element
+func leadingCombinator(s Sel) byte {
+	var combinator byte
+	for t, ok := s.(*CombinatorSelector); ok; t, ok = t.left.(*CombinatorSelector) {
+		combinator = t.combinator
+	}
+
+	return combinator
+}
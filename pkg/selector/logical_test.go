@@ -0,0 +1,111 @@
+package selector
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+func TestPseudoParser_ParseLogical(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     Sel
+		wantErr  bool
+	}{
+		{
+			name:     "parse :not",
+			selector: ":not(.a)",
+			want:     &LogicalSelector{name: "not", sel: &ClassSelector{class: "a"}},
+		},
+		{
+			name:     "parse :is with selector list",
+			selector: ":is( h1, ul > li )",
+			want: &LogicalSelector{name: "is", sel: &SelectorList{sels: []Sel{
+				&TagSelector{tag: "h1", name: "h1", atom: atom.H1},
+				&CombinatorSelector{left: &TagSelector{tag: "ul", name: "ul", atom: atom.Ul}, combinator: '>', right: &TagSelector{tag: "li", name: "li", atom: atom.Li}},
+			}}},
+		},
+		{
+			name:     "parse nested :where and :not",
+			selector: ":where(:not(p))",
+			want: &LogicalSelector{name: "where", sel: &LogicalSelector{
+				name: "not",
+				sel:  &TagSelector{tag: "p", name: "p", atom: atom.P},
+			}},
+		},
+		{
+			name:     "parse :has with relative selectors",
+			selector: ":has(> img, a span)",
+			want: &HasSelector{sels: []Sel{
+				&CombinatorSelector{left: &anchorSelector{}, combinator: '>', right: &TagSelector{tag: "img", name: "img", atom: atom.Img}},
+				&CombinatorSelector{
+					left:       &CombinatorSelector{left: &anchorSelector{}, combinator: ' ', right: &TagSelector{tag: "a", name: "a", atom: atom.A}},
+					combinator: ' ',
+					right:      &TagSelector{tag: "span", name: "span", atom: atom.Span},
+				},
+			}},
+		},
+		{name: "throw error for empty :not", selector: ":not()", want: nil, wantErr: true},
+		{name: "throw error for unclosed :is", selector: ":is(a", want: nil, wantErr: true},
+		{name: "throw error for relative selector in :is", selector: ":is(> a)", want: nil, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			got, err := Compile(tt.selector)
+			if (err != nil) != tt.wantErr {
+				t1.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("Parse() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogicalSelector_Match(t *testing.T) {
+	doc := `<html><body>
+<ul id="list">
+	<li id="first"><a id="link" href="#">Link</a></li>
+	<li id="second" class="item"><img id="img"></li>
+	<li id="third" class="item"><p id="p"><img id="deep"></p></li>
+</ul>
+<h1 id="h1"></h1>
+<h2 id="h2" class="item"></h2>
+</body></html>`
+	tests := []struct {
+		name     string
+		selector string
+		want     []string
+	}{
+		{name: "match :not", selector: "li:not(.item)", want: []string{"first"}},
+		{name: "match :not with selector list", selector: "li:not(#first, #third)", want: []string{"second"}},
+		{name: "match :is", selector: ":is(h1, h2)", want: []string{"h1", "h2"}},
+		{name: "match :where with complex selector", selector: ".item:where(ul > li)", want: []string{"second", "third"}},
+		{name: "match :has descendant", selector: "li:has(a)", want: []string{"first"}},
+		{name: "match :has child", selector: "li:has(> img)", want: []string{"second"}},
+		{name: "match :has descendant deep", selector: "li:has(img)", want: []string{"second", "third"}},
+		{name: "match :has complex relative selector", selector: "li:has(> p img)", want: []string{"third"}},
+		{name: "match :has next sibling", selector: "li:has(+ .item)", want: []string{"first", "second"}},
+		{name: "match :has subsequent sibling", selector: ":has(~ h2)", want: []string{"list", "h1"}},
+		{name: "match :has subsequent sibling descendant", selector: "li:has(~ li > p)", want: []string{"first", "second"}},
+		{name: "match :not :has", selector: "li:not(:has(img))", want: []string{"first"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			root, _ := html.Parse(strings.NewReader(doc))
+			s, err := Compile(tt.selector)
+			if err != nil {
+				t1.Errorf("Compile() error = %v", err)
+				return
+			}
+			if got := ids(QueryAll(root, s)); !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package selector
+
+// namespaceNames translates the namespace URIs to the names used by html.Node and html.Attribute,
+// any other namespace is used as is
+var namespaceNames = map[string]string{
+	"http://www.w3.org/1999/xhtml":         "",
+	"http://www.w3.org/2000/svg":           "svg",
+	"http://www.w3.org/1998/Math/MathML":   "math",
+	"http://www.w3.org/1999/xlink":         "xlink",
+	"http://www.w3.org/XML/1998/namespace": "xml",
+	"http://www.w3.org/2000/xmlns/":        "xmlns",
+}
+
+// namespaceComponent is the optional namespace prefix of type, universal and attribute selectors (formats 'ns|', '*|' and '|')
+// as defined in https://drafts.csswg.org/selectors-4/#type-nmsp
+type namespaceComponent struct {
+	prefix     string // written before '|', '*' for any namespace and empty for no namespace
+	qualified  bool   // the selector was written with a prefix, String only serializes it in that case
+	namespace  string // namespace name as found in html.Node, only checked when restricted
+	restricted bool
+	// none restricts to no namespace, it is not the empty namespace of html.Node that stands for the html namespace
+	none bool
+}
+
+// matches checks if namespace satisfies the component, elements without a namespace component are in any namespace
+// unless a default namespace was declared, the elements parsed by html are always in a namespace
+func (c namespaceComponent) matches(namespace string) bool {
+	switch {
+	case c.none:
+		return false
+	case c.restricted:
+		return c.namespace == namespace
+	}
+
+	return true
+}
+
+// matchesAttribute checks if namespace satisfies the component, attributes without a namespace component
+// are not in any namespace https://drafts.csswg.org/selectors-4/#attrnmsp
+func (c namespaceComponent) matchesAttribute(namespace string) bool {
+	if !c.qualified || c.none { // html.Attribute uses the empty namespace for no namespace
+		return namespace == ""
+	}
+
+	return c.matches(namespace)
+}
+
+func (c namespaceComponent) String() string {
+	switch {
+	case !c.qualified:
+		return ""
+	case c.prefix == "*":
+		return "*|"
+	}
+
+	return serializeIdentifier(c.prefix) + "|"
+}
+
+// namespaceName returns the name used by html.Node for the namespace URI
+func namespaceName(uri string) string {
+	if name, ok := namespaceNames[uri]; ok {
+		return name
+	}
+
+	return uri
+}
+
+// scanNamespacePrefix consumes a namespace prefix and its '|', the position is restored when there is none
+func (s *SelParser) scanNamespacePrefix() (string, bool) {
+	start := s.pos
+	prefix := "*"
+	if s.pos < s.selLen && s.sel[s.pos] == '*' {
+		s.pos++
+	} else if ident, err := s.parseIdentifier(); err == nil {
+		prefix = ident
+	}
+
+	// '|=' is the dash match operation of the attribute selectors, not a namespace separator
+	if s.pos < s.selLen && s.sel[s.pos] == '|' && (s.pos+1 >= s.selLen || s.sel[s.pos+1] != '=') {
+		s.pos++
+		return prefix, true
+	}
+	s.pos = start
+
+	return "", false
+}
+
+// parseNamespace parses the namespace component resolving the prefix with the declared namespaces,
+// the default namespace only applies to elements
+// as defined in https://drafts.csswg.org/css-namespaces-3/#css-qnames
+func (s *SelParser) parseNamespace(element bool) (namespaceComponent, error) {
+	start := s.pos
+	prefix, ok := s.scanNamespacePrefix()
+	switch {
+	case !ok && element:
+		if uri, ok := s.namespaces[""]; ok {
+			return restrictNamespace("", false, uri), nil
+		}
+		return namespaceComponent{}, nil
+	case !ok:
+		return namespaceComponent{}, nil
+	case prefix == "*":
+		return namespaceComponent{prefix: prefix, qualified: true}, nil
+	case prefix == "":
+		return namespaceComponent{qualified: true, none: true}, nil
+	}
+
+	uri, ok := s.namespaces[prefix]
+	if !ok {
+		return namespaceComponent{}, s.newErrorAt(start, s.sel[start:s.pos], "declared namespace prefix")
+	}
+
+	return restrictNamespace(prefix, true, uri), nil
+}
+
+// restrictNamespace returns the component restricted to the namespace uri, the empty uri declares no namespace
+func restrictNamespace(prefix string, qualified bool, uri string) namespaceComponent {
+	if uri == "" {
+		return namespaceComponent{prefix: prefix, qualified: qualified, none: true}
+	}
+
+	return namespaceComponent{prefix: prefix, qualified: qualified, namespace: namespaceName(uri), restricted: true}
+}
@@ -0,0 +1,183 @@
+package selector
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+const namespaceDoc = `<html><body>
+<div id="div"><a id="link" href="#div">Link</a></div>
+<lineargradient id="html-gradient"></lineargradient>
+<svg id="svg">
+	<a id="svg-link" xlink:href="#rect"><rect id="rect"></rect></a>
+	<linearGradient id="gradient"></linearGradient>
+	<foreignObject id="foreign"><div id="foreign-div"></div></foreignObject>
+</svg>
+<math id="math"><mi id="mi">x</mi></math>
+</body></html>`
+
+var testNamespaces = map[string]string{
+	"svg":   "http://www.w3.org/2000/svg",
+	"math":  "http://www.w3.org/1998/Math/MathML",
+	"xlink": "http://www.w3.org/1999/xlink",
+	"h":     "http://www.w3.org/1999/xhtml",
+}
+
+func TestCompiler_Namespaces(t *testing.T) {
+	tests := []struct {
+		name       string
+		selector   string
+		namespaces map[string]string
+		want       Sel
+	}{
+		{
+			name:     "parse a type selector with prefix",
+			selector: "svg|rect",
+			want:     &TagSelector{tag: "rect", name: "rect", ns: namespaceComponent{prefix: "svg", qualified: true, namespace: "svg", restricted: true}},
+		},
+		{
+			name:     "parse a type selector in any namespace",
+			selector: "*|rect",
+			want:     &TagSelector{tag: "rect", name: "rect", ns: namespaceComponent{prefix: "*", qualified: true}},
+		},
+		{
+			name:     "parse a type selector without namespace",
+			selector: "|rect",
+			want:     &TagSelector{tag: "rect", name: "rect", ns: namespaceComponent{qualified: true, none: true}},
+		},
+		{
+			name:       "parse a type selector in the default namespace",
+			selector:   "rect",
+			namespaces: map[string]string{"": "http://www.w3.org/2000/svg"},
+			want:       &TagSelector{tag: "rect", name: "rect", ns: namespaceComponent{namespace: "svg", restricted: true}},
+		},
+		{
+			name:     "parse a universal selector with prefix",
+			selector: "svg|*",
+			want:     &UniversalSelector{ns: namespaceComponent{prefix: "svg", qualified: true, namespace: "svg", restricted: true}},
+		},
+		{
+			name:     "parse an attribute selector with prefix",
+			selector: "[xlink|href]",
+			want:     &AttrSelector{key: "href", ns: namespaceComponent{prefix: "xlink", qualified: true, namespace: "xlink", restricted: true}},
+		},
+		{
+			name:     "parse an attribute selector with dash match operation",
+			selector: `[lang|="en"]`,
+			want:     &AttrSelector{key: "lang", op: "|=", val: "en"},
+		},
+		{
+			name:       "parse an attribute selector ignoring the default namespace",
+			selector:   "[href]",
+			namespaces: map[string]string{"": "http://www.w3.org/2000/svg"},
+			want:       &AttrSelector{key: "href"},
+		},
+		{
+			name:       "parse a namespace given by its html name",
+			selector:   "s|rect",
+			namespaces: map[string]string{"s": "svg"},
+			want:       &TagSelector{tag: "rect", name: "rect", ns: namespaceComponent{prefix: "s", qualified: true, namespace: "svg", restricted: true}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			namespaces := tt.namespaces
+			if namespaces == nil {
+				namespaces = testNamespaces
+			}
+
+			got, err := Compiler{Namespaces: namespaces}.Compile(tt.selector)
+			if err != nil {
+				t1.Errorf("Compile() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("Compile() = %v, want %v", got, tt.want)
+			}
+			if got.String() != tt.selector {
+				t1.Errorf("String() = %v, want %v", got.String(), tt.selector)
+			}
+		})
+	}
+}
+
+func TestCompiler_Namespaces_Match(t *testing.T) {
+	tests := []struct {
+		name       string
+		selector   string
+		namespaces map[string]string
+		want       []string
+	}{
+		{name: "query svg elements", selector: "svg|*", want: []string{"svg", "svg-link", "rect", "gradient", "foreign"}},
+		{name: "query html links only", selector: "h|a", want: []string{"link"}},
+		{name: "query links in any namespace", selector: "*|a", want: []string{"link", "svg-link"}},
+		{name: "query links without namespace prefix", selector: "a", want: []string{"link", "svg-link"}},
+		{name: "query svg element with mixed case name", selector: "svg|linearGradient", want: []string{"gradient"}},
+		{name: "query html element ignoring case", selector: "linearGradient", want: []string{"html-gradient", "gradient"}},
+		{name: "query html element only with other case", selector: "lineargradient", want: []string{"html-gradient"}},
+		{name: "not query svg element with other case", selector: "svg|foreignobject", want: nil},
+		{name: "query html inside svg", selector: "svg|foreignObject > h|div", want: []string{"foreign-div"}},
+		{name: "query mathml elements", selector: "math|mi", want: []string{"mi"}},
+		{name: "query nothing without namespace", selector: "|div", want: nil},
+		{name: "query nothing in the empty namespace", selector: "e|*", namespaces: map[string]string{"e": ""}, want: nil},
+		{name: "query namespaced attribute", selector: "[xlink|href]", want: []string{"svg-link"}},
+		{name: "query attribute without namespace by '|'", selector: "[|href]", want: []string{"link"}},
+		{name: "query attribute without namespace", selector: "[href]", want: []string{"link"}},
+		{name: "query attribute in any namespace", selector: `[*|href^="#"]`, want: []string{"link", "svg-link"}},
+		{
+			name:       "query elements of the default namespace",
+			selector:   "a",
+			namespaces: map[string]string{"": "http://www.w3.org/2000/svg"},
+			want:       []string{"svg-link"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			namespaces := tt.namespaces
+			if namespaces == nil {
+				namespaces = testNamespaces
+			}
+
+			root, _ := html.Parse(strings.NewReader(namespaceDoc))
+			s, err := Compiler{Namespaces: namespaces}.Compile(tt.selector)
+			if err != nil {
+				t1.Errorf("Compile() error = %v", err)
+				return
+			}
+			if got := ids(QueryAll(root, s)); !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("QueryAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompiler_Namespaces_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		offset   int
+		token    string
+	}{
+		{name: "undeclared type selector prefix", selector: "ul > foo|li", offset: 5, token: "foo|"},
+		{name: "undeclared attribute prefix", selector: "[foo|href]", offset: 1, token: "foo|"},
+		{name: "prefix without name", selector: "svg|", offset: 4, token: ""},
+		{name: "prefix after other simple selector", selector: ".a|b", offset: 2, token: "|"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			_, err := Compiler{Namespaces: testNamespaces}.Compile(tt.selector)
+			var selErr *SelectorError
+			if !errors.As(err, &selErr) {
+				t1.Errorf("Compile() error = %v, want *SelectorError", err)
+				return
+			}
+			if selErr.Offset != tt.offset || selErr.Token != tt.token {
+				t1.Errorf("Compile() error at %d with token %q, want at %d with token %q", selErr.Offset, selErr.Token, tt.offset, tt.token)
+			}
+		})
+	}
+}
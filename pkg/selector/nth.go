@@ -0,0 +1,223 @@
+package selector
+
+import (
+	"strconv"
+
+	"golang.org/x/net/html"
+)
+
+type NthSelector struct {
+	a      int
+	b      int
+	last   bool
+	ofType bool
+	of     Sel
+}
+
+func (t NthSelector) Match(n *html.Node) bool {
+	if html.ElementNode != n.Type || t.of != nil && !t.of.Match(n) {
+		return false
+	}
+
+	sibling := previousElementSibling
+	if t.last {
+		sibling = nextElementSibling
+	}
+
+	pos := 1
+	for s := sibling(n); s != nil; s = sibling(s) {
+		switch {
+		case t.ofType && (s.Data != n.Data || s.Namespace != n.Namespace):
+		case t.of != nil && !t.of.Match(s):
+		default:
+			pos++
+		}
+	}
+
+	return t.matchPosition(pos)
+}
+
+// Specificity returns the specificity of a pseudo-class plus the one of the most specific selector of the 'of' clause
+func (t NthSelector) Specificity() [3]int {
+	if t.of == nil {
+		return [3]int{0, 1, 0}
+	}
+
+	return addSpecificity([3]int{0, 1, 0}, t.of.Specificity())
+}
+
+func (t NthSelector) String() string {
+	name := "nth-child"
+	switch {
+	case t.last && t.ofType:
+		name = "nth-last-of-type"
+	case t.last:
+		name = "nth-last-child"
+	case t.ofType:
+		name = "nth-of-type"
+	}
+
+	of := ""
+	if t.of != nil {
+		of = " of " + t.of.String()
+	}
+
+	return ":" + name + "(" + t.serializeAnB() + of + ")"
+}
+
+// serializeAnB returns the canonical An+B notation
+// as defined in https://drafts.csswg.org/css-syntax-3/#serializing-anb
+func (t NthSelector) serializeAnB() string {
+	if t.a == 0 {
+		return strconv.Itoa(t.b)
+	}
+
+	var str string
+	switch t.a {
+	case 1:
+		str = "n"
+	case -1:
+		str = "-n"
+	default:
+		str = strconv.Itoa(t.a) + "n"
+	}
+
+	switch {
+	case t.b > 0:
+		str += "+" + strconv.Itoa(t.b)
+	case t.b < 0:
+		str += strconv.Itoa(t.b)
+	}
+
+	return str
+}
+
+// matchPosition checks if exists an integer n >= 0 satisfying a*n + b = pos
+func (t NthSelector) matchPosition(pos int) bool {
+	if t.a == 0 {
+		return pos == t.b
+	}
+
+	return (pos-t.b)%t.a == 0 && (pos-t.b)/t.a >= 0
+}
+
+// parseNth parses the arguments of :nth-child(), :nth-last-child(), :nth-of-type() and :nth-last-of-type()
+// as defined in https://drafts.csswg.org/selectors-4/#child-index
+func (p PseudoParser) parseNth(name string) (Sel, error) {
+	a, b, err := p.parseAnB()
+	if err != nil {
+		return nil, err
+	}
+
+	sel := &NthSelector{
+		a:      a,
+		b:      b,
+		last:   name == "nth-last-child" || name == "nth-last-of-type",
+		ofType: name == "nth-of-type" || name == "nth-last-of-type",
+	}
+
+	if !sel.ofType && p.skipWhitespace() && p.pos+2 < p.selLen && toASCIILower(p.sel[p.pos:p.pos+2]) == "of" &&
+		p.isWhitespace(p.sel[p.pos+2]) {
+		p.pos += 2
+		if sel.of, err = (&SelectorParser{p.SelParser}).parseList(); err != nil {
+			return nil, err
+		}
+	}
+
+	return sel, nil
+}
+
+// parseAnB parses the An+B notation (formats 'odd', 'even', '3', '-n+3', '2n + 1')
+// as defined in https://drafts.csswg.org/css-syntax-3/#anb-microsyntax
+func (p PseudoParser) parseAnB() (int, int, error) {
+	for _, keyword := range []struct {
+		name string
+		a, b int
+	}{{"odd", 2, 1}, {"even", 2, 0}} {
+		end := p.pos + len(keyword.name)
+		if end <= p.selLen && toASCIILower(p.sel[p.pos:end]) == keyword.name &&
+			(end == p.selLen || !p.isValidIdentifierChar(p.sel[end])) {
+			p.pos = end
+			return keyword.a, keyword.b, nil
+		}
+	}
+
+	sign := p.parseSign()
+	if sign == 0 {
+		sign = 1
+	}
+	start := p.pos
+	digits := p.parseDigits()
+	if p.pos >= p.selLen || p.sel[p.pos] != 'n' && p.sel[p.pos] != 'N' {
+		if digits == "" {
+			return 0, 0, p.newError("An+B notation (odd, even, An+B)")
+		}
+
+		b, err := p.parseInteger(start, digits)
+		return 0, sign * b, err
+	}
+	p.pos++
+
+	a := 1
+	if digits != "" {
+		var err error
+		if a, err = p.parseInteger(start, digits); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	// B is optional and can be separated from An by whitespace https://drafts.csswg.org/css-syntax-3/#anb-syntax
+	start = p.pos
+	p.skipWhitespace()
+	bSign := p.parseSign()
+	if bSign == 0 {
+		p.pos = start
+		return sign * a, 0, nil
+	}
+	p.skipWhitespace()
+
+	start = p.pos
+	digits = p.parseDigits()
+	if digits == "" {
+		return 0, 0, p.newError("B of An+B notation (integer)")
+	}
+	b, err := p.parseInteger(start, digits)
+
+	return sign * a, bSign * b, err
+}
+
+// parseSign parses an optional sign returning 1 for '+', -1 for '-' and 0 when there is no sign
+func (p PseudoParser) parseSign() int {
+	if p.pos < p.selLen {
+		switch p.sel[p.pos] {
+		case '+':
+			p.pos++
+			return 1
+		case '-':
+			p.pos++
+			return -1
+		}
+	}
+
+	return 0
+}
+
+// parseDigits parses consecutive decimal digits
+func (p PseudoParser) parseDigits() string {
+	start := p.pos
+	for p.pos < p.selLen && '0' <= p.sel[p.pos] && p.sel[p.pos] <= '9' {
+		p.pos++
+	}
+
+	return p.sel[start:p.pos]
+}
+
+// parseInteger converts the digits found at start to an integer
+func (p PseudoParser) parseInteger(start int, digits string) (int, error) {
+	i, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0, p.newCauseError(err, start, digits, "integer")
+	}
+
+	return i, nil
+}
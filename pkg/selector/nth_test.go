@@ -0,0 +1,107 @@
+package selector
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+func TestPseudoParser_ParseNth(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     Sel
+		wantErr  bool
+	}{
+		{name: "parse odd", selector: ":nth-child(odd)", want: &NthSelector{a: 2, b: 1}},
+		{name: "parse even ignoring case", selector: ":nth-child( EVEN )", want: &NthSelector{a: 2, b: 0}},
+		{name: "parse integer", selector: ":nth-child(3)", want: &NthSelector{a: 0, b: 3}},
+		{name: "parse signed integer", selector: ":nth-child(-3)", want: &NthSelector{a: 0, b: -3}},
+		{name: "parse n", selector: ":nth-child(n)", want: &NthSelector{a: 1, b: 0}},
+		{name: "parse -n+3", selector: ":nth-child(-n+3)", want: &NthSelector{a: -1, b: 3}},
+		{name: "parse +2n-1", selector: ":nth-child(+2N-1)", want: &NthSelector{a: 2, b: -1}},
+		{name: "parse An + B with whitespace", selector: ":nth-child( 3n + 2 )", want: &NthSelector{a: 3, b: 2}},
+		{name: "parse An - B with whitespace", selector: ":nth-child(3n - 2)", want: &NthSelector{a: 3, b: -2}},
+		{name: "parse :nth-last-child", selector: ":nth-last-child(2)", want: &NthSelector{a: 0, b: 2, last: true}},
+		{name: "parse :nth-of-type", selector: ":nth-of-type(2n)", want: &NthSelector{a: 2, b: 0, ofType: true}},
+		{
+			name:     "parse :nth-last-of-type",
+			selector: ":nth-last-of-type(2n)",
+			want:     &NthSelector{a: 2, b: 0, last: true, ofType: true},
+		},
+		{
+			name:     "parse :nth-child with of selector",
+			selector: ":nth-child(2n+1 of li.item, p)",
+			want: &NthSelector{a: 2, b: 1, of: &SelectorList{sels: []Sel{
+				&CompoundSelector{sels: []Sel{&TagSelector{tag: "li", name: "li", atom: atom.Li}, &ClassSelector{class: "item"}}},
+				&TagSelector{tag: "p", name: "p", atom: atom.P},
+			}}},
+		},
+		{name: "throw error for of selector in :nth-of-type", selector: ":nth-of-type(2n of li)", want: nil, wantErr: true},
+		{name: "throw error for sign separated from n", selector: ":nth-child(- n)", want: nil, wantErr: true},
+		{name: "throw error for missing B", selector: ":nth-child(2n+)", want: nil, wantErr: true},
+		{name: "throw error for empty arguments", selector: ":nth-child()", want: nil, wantErr: true},
+		{name: "throw error for unclosed arguments", selector: ":nth-child(2n", want: nil, wantErr: true},
+		{name: "throw error for unknown functional pseudo-class", selector: ":nth-unknown(2n)", want: nil, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			got, err := Compile(tt.selector)
+			if (err != nil) != tt.wantErr {
+				t1.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("Parse() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNthSelector_Match(t *testing.T) {
+	doc := `<html><body><table>
+<tr id="r1"><td id="c11"></td><td id="c12"></td></tr>
+<tr id="r2" class="hidden"><td id="c21"></td></tr>
+<tr id="r3"><th id="h31"></th><td id="c31"></td><th id="h32"></th><td id="c32"></td></tr>
+<tr id="r4"></tr>
+<tr id="r5"></tr>
+</table></body></html>`
+	tests := []struct {
+		name     string
+		selector string
+		want     []string
+	}{
+		{name: "match odd rows", selector: "tr:nth-child(odd)", want: []string{"r1", "r3", "r5"}},
+		{name: "match even rows", selector: "tr:nth-child(even)", want: []string{"r2", "r4"}},
+		{name: "match second row", selector: "tr:nth-child(2)", want: []string{"r2"}},
+		{name: "match first three rows", selector: "tr:nth-child(-n+3)", want: []string{"r1", "r2", "r3"}},
+		{name: "match rows from the fourth", selector: "tr:nth-child(n+4)", want: []string{"r4", "r5"}},
+		{name: "match every third row starting at second", selector: "tr:nth-child(3n-1)", want: []string{"r2", "r5"}},
+		{name: "match no row for position 0", selector: "tr:nth-child(0)", want: nil},
+		{name: "match last row", selector: "tr:nth-last-child(1)", want: []string{"r5"}},
+		{name: "match second column", selector: "td:nth-child(2)", want: []string{"c12", "c31"}},
+		{name: "match second td column", selector: "td:nth-of-type(2)", want: []string{"c12", "c32"}},
+		{name: "match last th column", selector: "th:nth-last-of-type(1)", want: []string{"h32"}},
+		{
+			name:     "match even rows that are not hidden",
+			selector: "tr:nth-child(even of tr:first-child, #r3, #r4, #r5)",
+			want:     []string{"r3", "r5"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			root, _ := html.Parse(strings.NewReader(doc))
+			s, err := Compile(tt.selector)
+			if err != nil {
+				t1.Errorf("Compile() error = %v", err)
+				return
+			}
+			if got := ids(QueryAll(root, s)); !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
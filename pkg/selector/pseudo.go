@@ -0,0 +1,194 @@
+package selector
+
+import (
+	"golang.org/x/net/html"
+)
+
+type PseudoSelector struct {
+	name string
+}
+
+func (t PseudoSelector) Match(n *html.Node) bool {
+	if html.ElementNode != n.Type {
+		return false
+	}
+
+	switch t.name {
+	case "root": // https://drafts.csswg.org/selectors-4/#the-root-pseudo
+		return n.Parent != nil && html.DocumentNode == n.Parent.Type
+	case "empty": // https://drafts.csswg.org/selectors-4/#the-empty-pseudo
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if html.ElementNode == c.Type || html.TextNode == c.Type && c.Data != "" {
+				return false
+			}
+		}
+		return true
+	case "first-child": // https://drafts.csswg.org/selectors-4/#the-first-child-pseudo
+		return previousElementSibling(n) == nil
+	case "last-child": // https://drafts.csswg.org/selectors-4/#the-last-child-pseudo
+		return nextElementSibling(n) == nil
+	case "only-child": // https://drafts.csswg.org/selectors-4/#the-only-child-pseudo
+		return previousElementSibling(n) == nil && nextElementSibling(n) == nil
+	case "checked":
+		return isChecked(n)
+	case "disabled":
+		return isDisabled(n)
+	case "enabled":
+		return isEnabled(n)
+	case "required":
+		return isRequired(n)
+	case "optional":
+		return isOptional(n)
+	case "read-write":
+		return isReadWrite(n)
+	case "read-only":
+		return !isReadWrite(n)
+	case "placeholder-shown":
+		return isPlaceholderShown(n)
+	case "link", "any-link":
+		return isLink(n)
+	}
+
+	return false
+}
+
+func (t PseudoSelector) Specificity() [3]int {
+	return [3]int{0, 1, 0}
+}
+
+func (t PseudoSelector) String() string {
+	return ":" + t.name
+}
+
+var pseudoClasses = map[string]bool{
+	"root":              true,
+	"empty":             true,
+	"first-child":       true,
+	"last-child":        true,
+	"only-child":        true,
+	"checked":           true,
+	"disabled":          true,
+	"enabled":           true,
+	"required":          true,
+	"optional":          true,
+	"read-write":        true,
+	"read-only":         true,
+	"placeholder-shown": true,
+	"link":              true,
+	"any-link":          true,
+}
+
+var functionalPseudoClasses = map[string]bool{
+	"nth-child":        true,
+	"nth-last-child":   true,
+	"nth-of-type":      true,
+	"nth-last-of-type": true,
+	"not":              true,
+	"is":               true,
+	"where":            true,
+	"has":              true,
+	"contains":         true,
+	"containsown":      true,
+	"matches":          true,
+}
+
+// extensionPseudoClasses are the non-standard pseudo-classes only available when the compiler enables extensions
+var extensionPseudoClasses = map[string]bool{
+	"contains":    true,
+	"containsown": true,
+	"matches":     true,
+}
+
+type PseudoParser struct {
+	*SelParser
+}
+
+func NewPseudoParser(sel string) *PseudoParser {
+	return &PseudoParser{
+		&SelParser{
+			sel:    sel,
+			selLen: len(sel),
+		},
+	}
+}
+
+func (p PseudoParser) Parse() (Sel, error) {
+	if p.pos >= p.selLen || p.sel[p.pos] != ':' {
+		return nil, p.newError("pseudo-class selector (:name)")
+	}
+	start := p.pos
+	p.pos++
+	if p.pos < p.selLen && p.sel[p.pos] == ':' { // pseudo-elements are not supported
+		return nil, p.newError("pseudo-class name")
+	}
+
+	name, err := p.parseIdentifier()
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		return nil, p.newError("pseudo-class name")
+	}
+
+	name = toASCIILower(name) // pseudo-class names are ASCII case-insensitive
+	if p.pos < p.selLen && p.sel[p.pos] == '(' {
+		if !functionalPseudoClasses[name] || !p.extensions && extensionPseudoClasses[name] {
+			return nil, p.newErrorAt(start, p.sel[start:p.pos+1], "functional pseudo-class (:name())")
+		}
+		p.pos++
+		p.skipWhitespace()
+
+		sel, err := p.parseArguments(name)
+		if err != nil {
+			return nil, err
+		}
+
+		p.skipWhitespace()
+		if p.pos >= p.selLen || p.sel[p.pos] != ')' {
+			return nil, p.newError("end of pseudo-class arguments ())")
+		}
+		p.pos++
+
+		return sel, nil
+	}
+	if !pseudoClasses[name] {
+		return nil, p.newErrorAt(start, p.sel[start:p.pos], "pseudo-class (:name)")
+	}
+
+	return &PseudoSelector{
+		name: name,
+	}, nil
+}
+
+// parseArguments parses the arguments of a functional pseudo-class
+func (p PseudoParser) parseArguments(name string) (Sel, error) {
+	switch name {
+	case "nth-child", "nth-last-child", "nth-of-type", "nth-last-of-type":
+		return p.parseNth(name)
+	case "not", "is", "where":
+		sel, err := (&SelectorParser{p.SelParser}).parseList()
+		if err != nil {
+			return nil, err
+		}
+
+		return &LogicalSelector{
+			name: name,
+			sel:  sel,
+		}, nil
+	case "contains", "containsown", "matches":
+		return p.parseText(name)
+	case "has":
+		s := &SelectorParser{p.SelParser}
+		sels, err := s.parseCommaSeparated(s.parseRelative)
+		if err != nil {
+			return nil, err
+		}
+
+		return &HasSelector{
+			sels: sels,
+		}, nil
+	}
+
+	return nil, p.newError("arguments of :" + name + "()")
+}
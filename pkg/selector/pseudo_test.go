@@ -0,0 +1,78 @@
+package selector
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestPseudoParser_Parse(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     Sel
+		wantErr  bool
+	}{
+		{name: "parse :first-child", selector: ":first-child", want: &PseudoSelector{name: "first-child"}},
+		{name: "parse :last-child", selector: ":last-child", want: &PseudoSelector{name: "last-child"}},
+		{name: "parse :only-child", selector: ":only-child", want: &PseudoSelector{name: "only-child"}},
+		{name: "parse :empty", selector: ":empty", want: &PseudoSelector{name: "empty"}},
+		{name: "parse :root ignoring case", selector: ":ROOT", want: &PseudoSelector{name: "root"}},
+		{name: "parse pseudo-class with escaped letter", selector: `:r\6f ot`, want: &PseudoSelector{name: "root"}},
+		{name: "throw error for unknown pseudo-class", selector: ":unknown", want: nil, wantErr: true},
+		{name: "throw error for pseudo-element", selector: "::before", want: nil, wantErr: true},
+		{name: "throw error for bad pseudo-class selector", selector: "root", want: nil, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			t := NewPseudoParser(tt.selector)
+			got, err := t.Parse()
+			if (err != nil) != tt.wantErr {
+				t1.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("Parse() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPseudoSelector_Match(t *testing.T) {
+	doc := `<html id="root"><body>
+<ul id="list">
+	<li id="first">Element 1</li>
+	<!-- comment -->
+	<li id="second"></li>
+	<li id="third"><span id="only"></span></li>
+</ul>
+<p id="empty"><!-- comment --></p>
+</body></html>`
+	tests := []struct {
+		name     string
+		selector string
+		want     []string
+	}{
+		{name: "match :root", selector: ":root", want: []string{"root"}},
+		{name: "match :first-child", selector: "li:first-child", want: []string{"first"}},
+		{name: "match :last-child", selector: "li:last-child", want: []string{"third"}},
+		{name: "match :only-child", selector: ":only-child", want: []string{"root", "only"}},
+		{name: "match :empty ignoring comments", selector: ":empty", want: []string{"second", "only", "empty"}},
+		{name: "match :first-child:last-child as :only-child", selector: "span:first-child:last-child", want: []string{"only"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			root, _ := html.Parse(strings.NewReader(doc))
+			s, err := Compile(tt.selector)
+			if err != nil {
+				t1.Errorf("Compile() error = %v", err)
+				return
+			}
+			if got := ids(QueryAll(root, s)); !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
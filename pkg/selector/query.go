@@ -0,0 +1,111 @@
+package selector
+
+import (
+	"golang.org/x/net/html"
+)
+
+// QueryAll returns the descendant elements of root matching s in document order
+func QueryAll(root *html.Node, s Sel) []*html.Node {
+	var nodes []*html.Node
+	m := newMatcher(root, s)
+	walk(root, m.ancestors, func(n *html.Node) bool {
+		if m.match(n) {
+			nodes = append(nodes, n)
+		}
+
+		return true
+	})
+
+	return nodes
+}
+
+// QueryFirst returns the first descendant element of root matching s in document order, nil if none matches
+func QueryFirst(root *html.Node, s Sel) *html.Node {
+	var node *html.Node
+	m := newMatcher(root, s)
+	walk(root, m.ancestors, func(n *html.Node) bool {
+		if m.match(n) {
+			node = n
+			return false
+		}
+
+		return true
+	})
+
+	return node
+}
+
+// Filter returns the elements of nodes matching s keeping their order
+func Filter(nodes []*html.Node, s Sel) []*html.Node {
+	var filtered []*html.Node
+	for _, n := range nodes {
+		if html.ElementNode == n.Type && s.Match(n) {
+			filtered = append(filtered, n)
+		}
+	}
+
+	return filtered
+}
+
+// walk visits the descendant elements of n in document order until visit returns false,
+// when ancestors is not nil it holds the ancestors of the visited element
+func walk(n *html.Node, ancestors *ancestorMatches, visit func(n *html.Node) bool) bool {
+	for e := n.FirstChild; e != nil; e = e.NextSibling {
+		if html.ElementNode != e.Type {
+			continue
+		}
+		if !visit(e) {
+			return false
+		}
+
+		if ancestors != nil {
+			ancestors.push(e)
+		}
+		found := walk(e, ancestors, visit)
+		if ancestors != nil {
+			ancestors.pop()
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matcher matches the elements visited by walk, the combinators read the results of the ancestors
+// kept by ancestorMatches instead of walking the ancestors for every element
+type matcher struct {
+	sels      []Sel
+	ancestors *ancestorMatches // nil when no selector has ' ' or '>' combinators
+}
+
+// newMatcher prepares s to match the descendants of root
+func newMatcher(root *html.Node, s Sel) *matcher {
+	m := &matcher{
+		sels: []Sel{s},
+	}
+	if l, ok := s.(*SelectorList); ok {
+		m.sels = l.sels
+	}
+	m.ancestors = newAncestorMatches(root, m.sels)
+
+	return m
+}
+
+// match checks if any selector matches n
+func (m *matcher) match(n *html.Node) bool {
+	for _, sel := range m.sels {
+		var matched bool
+		if m.ancestors != nil {
+			matched = m.ancestors.matchVisited(sel, n)
+		} else {
+			matched = sel.Match(n)
+		}
+		if matched {
+			return true
+		}
+	}
+
+	return false
+}
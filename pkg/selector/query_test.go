@@ -0,0 +1,221 @@
+package selector
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+const queryDoc = `<html><body>
+<h2 id="h2" class="title">Subtitle</h2>
+<ul id="list">
+	<li id="first" class="item">Element 1</li>
+	<li id="second" class="item">Element 2</li>
+</ul>
+<h1 id="h1">Title</h1>
+</body></html>`
+
+// ids returns the id attribute of every node
+func ids(nodes []*html.Node) []string {
+	var res []string
+	for _, n := range nodes {
+		for _, attr := range n.Attr {
+			if "id" == attr.Key {
+				res = append(res, attr.Val)
+			}
+		}
+	}
+
+	return res
+}
+
+func TestQueryAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     []string
+	}{
+		{name: "query every <li>", selector: "li", want: []string{"first", "second"}},
+		{name: "query selector list in document order", selector: "h1, h2, li", want: []string{"h2", "first", "second", "h1"}},
+		{name: "query selector list without duplicates", selector: "li, .item, #second", want: []string{"first", "second"}},
+		{name: "query nothing for unknown tag", selector: "table", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			root, _ := html.Parse(strings.NewReader(queryDoc))
+			s, err := Compile(tt.selector)
+			if err != nil {
+				t1.Errorf("Compile() error = %v", err)
+				return
+			}
+			if got := ids(QueryAll(root, s)); !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("QueryAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryFirst(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     []string
+	}{
+		{name: "query first <li>", selector: "li", want: []string{"first"}},
+		{name: "query first element of selector list in document order", selector: "h1, .title", want: []string{"h2"}},
+		{name: "query nil for unknown tag", selector: "table", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			root, _ := html.Parse(strings.NewReader(queryDoc))
+			s, err := Compile(tt.selector)
+			if err != nil {
+				t1.Errorf("Compile() error = %v", err)
+				return
+			}
+			var got []string
+			if n := QueryFirst(root, s); n != nil {
+				got = ids([]*html.Node{n})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("QueryFirst() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     []string
+	}{
+		{name: "filter elements with class 'item'", selector: ".item", want: []string{"first", "second"}},
+		{name: "filter headings", selector: "h1, h2", want: []string{"h2", "h1"}},
+		{name: "filter nothing for unknown tag", selector: "table", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			root, _ := html.Parse(strings.NewReader(queryDoc))
+			s, err := Compile(tt.selector)
+			if err != nil {
+				t1.Errorf("Compile() error = %v", err)
+				return
+			}
+			if got := ids(Filter(QueryAll(root, &UniversalSelector{}), s)); !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("Filter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// deepDoc returns a document nesting depth levels of <div> elements, each one with siblings to reach about 10k elements
+func deepDoc(depth int) string {
+	var doc strings.Builder
+	doc.WriteString("<html><body>")
+	for i := 0; i < depth; i++ {
+		fmt.Fprintf(&doc, `<div class="level%d %s">`, i, map[bool]string{true: "a", false: "b"}[i%7 == 0])
+		for j := 0; j < 50; j++ {
+			fmt.Fprintf(&doc, `<p class="sibling"><span id="span-%d-%d">text</span><a class="a">link</a></p>`, i, j)
+		}
+	}
+	doc.WriteString(strings.Repeat("</div>", depth))
+	doc.WriteString("</body></html>")
+
+	return doc.String()
+}
+
+// matchReference matches the combinators of s trying every candidate on their left side recursively,
+// a plain reference for the results of the right to left matching
+func matchReference(s Sel, n *html.Node) bool {
+	switch t := s.(type) {
+	case *SelectorList:
+		for _, sel := range t.sels {
+			if matchReference(sel, n) {
+				return true
+			}
+		}
+		return false
+	case *CombinatorSelector:
+		if !matchReference(t.right, n) {
+			return false
+		}
+
+		for c := n; ; {
+			switch t.combinator {
+			case '>', ' ':
+				c = c.Parent
+			default:
+				c = c.PrevSibling
+				for c != nil && html.ElementNode != c.Type {
+					c = c.PrevSibling
+				}
+			}
+			if c == nil || html.ElementNode != c.Type {
+				return false
+			}
+			if matchReference(t.left, c) {
+				return true
+			}
+			if t.combinator == '>' || t.combinator == '+' {
+				return false
+			}
+		}
+	}
+
+	return s.Match(n)
+}
+
+func TestQueryAll_DeepDocument(t *testing.T) {
+	root, _ := html.Parse(strings.NewReader(deepDoc(60)))
+	all := QueryAll(root, &UniversalSelector{})
+	selectors := []string{
+		"div .a span", "div.a > p.sibling > span", ".level59 span", ".level3 .level2 span", "#span-0-0 ~ a",
+		"body > div p + a", ".missing span", "div .a span, .level10 > p a", ".level1 > .level2 ~ p span",
+	}
+	for _, selector := range selectors {
+		t.Run(selector, func(t1 *testing.T) {
+			s, err := Compile(selector)
+			if err != nil {
+				t1.Errorf("Compile() error = %v", err)
+				return
+			}
+			var want []*html.Node
+			for _, n := range all {
+				if matchReference(s, n) {
+					want = append(want, n)
+				}
+			}
+			if got := QueryAll(root, s); !reflect.DeepEqual(got, want) {
+				t1.Errorf("QueryAll() found %d elements, want %d", len(got), len(want))
+			}
+			if got := Filter(all, s); !reflect.DeepEqual(got, want) {
+				t1.Errorf("Filter() found %d elements, want %d", len(got), len(want))
+			}
+		})
+	}
+}
+
+func BenchmarkQueryAll_DeepDocument(b *testing.B) {
+	root, _ := html.Parse(strings.NewReader(deepDoc(60)))
+	for _, selector := range []string{"div .a span", ".missing span", "div.a > p.sibling > span"} {
+		s, _ := Compile(selector)
+		b.Run(selector, func(b1 *testing.B) {
+			for i := 0; i < b1.N; i++ {
+				QueryAll(root, s)
+			}
+		})
+		// matching every element on its own walks its ancestors again, as Filter does
+		b.Run(selector+" walking ancestors", func(b1 *testing.B) {
+			for i := 0; i < b1.N; i++ {
+				walk(root, nil, func(n *html.Node) bool {
+					s.Match(n)
+					return true
+				})
+			}
+		})
+	}
+}
@@ -3,55 +3,256 @@ package selector
 import (
 	"fmt"
 	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"golang.org/x/net/html"
 )
 
 type Sel interface {
 	Match(n *html.Node) bool
+	// Specificity returns the (A, B, C) specificity as defined in https://drafts.csswg.org/selectors-4/#specificity-rules
+	Specificity() [3]int
+	// String returns the canonical CSS text of the selector, compiling it gives an equivalent selector
+	String() string
+}
+
+// CompareSpecificity returns 1 when a is more specific than b, -1 when b is more specific than a and 0 if equal
+func CompareSpecificity(a, b [3]int) int {
+	for i := range a {
+		switch {
+		case a[i] > b[i]:
+			return 1
+		case a[i] < b[i]:
+			return -1
+		}
+	}
+
+	return 0
+}
+
+// addSpecificity returns the component-wise sum of a and b
+func addSpecificity(a, b [3]int) [3]int {
+	return [3]int{a[0] + b[0], a[1] + b[1], a[2] + b[2]}
+}
+
+// maxSpecificity returns the greatest specificity of sels
+func maxSpecificity(sels []Sel) [3]int {
+	var specificity [3]int
+	for _, sel := range sels {
+		if s := sel.Specificity(); CompareSpecificity(s, specificity) > 0 {
+			specificity = s
+		}
+	}
+
+	return specificity
+}
+
+// getAttribute returns the value of the attribute key of n and if it was found
+func getAttribute(n *html.Node, key string) (string, bool) {
+	for _, attr := range n.Attr {
+		if "" == attr.Namespace && key == attr.Key {
+			return attr.Val, true
+		}
+	}
+
+	return "", false
+}
+
+// hasAttribute checks if n has the attribute key
+func hasAttribute(n *html.Node, key string) bool {
+	_, ok := getAttribute(n, key)
+	return ok
 }
 
 type Parser interface {
-	Parse(sel string) Sel
+	Parse() (Sel, error)
 }
 
 type SelParser struct {
-	sel    string
-	selLen int
-	pos    int
+	sel        string
+	selLen     int
+	pos        int
+	extensions bool
+	namespaces map[string]string
 }
 
-// parseEscape parses backslash escaped character (formats '\000026' or '\26 ' and 'U+000026' or 'U+0026')
+// parseEscape parses a backslash escaped character (formats '\.', '\000026' or '\26 ')
+// as defined in https://drafts.csswg.org/css-syntax-3/#consume-escaped-code-point
 func (s *SelParser) parseEscape() (string, error) {
-	if '\\' != s.sel[s.pos] {
-		return "", fmt.Errorf("expected escape element (\\), found '%c'", s.sel[s.pos])
+	if s.pos >= s.selLen || '\\' != s.sel[s.pos] {
+		return "", s.newError("escape element (\\)")
 	}
 	s.pos++
-
-	start := s.pos // this is for the pattern "\000026" and also works with "\26 "
-	if s.sel[start] == 'U' {
-		start = s.pos + 2 // this is for the pattern "\U+000026"
+	if s.pos >= s.selLen { // an escape at the end of the input is a replacement character
+		return string(utf8.RuneError), nil
+	}
+	if s.isNewLine(s.sel[s.pos]) {
+		return "", s.newError("escaped character")
 	}
 
-	var i int
-	for i = start; i < start+6 && i < s.selLen && s.isHexChar(s.sel[i]); i++ {
+	if !s.isHexChar(s.sel[s.pos]) { // escaped character is taken as is
+		r, size := utf8.DecodeRuneInString(s.sel[s.pos:])
+		s.pos += size
+		return string(r), nil
 	}
 
-	if i-start < 6 && s.sel[i] != ' ' {
-		s.pos += i - start
-		return s.sel[start:i], nil
+	start := s.pos
+	for s.pos < start+6 && s.pos < s.selLen && s.isHexChar(s.sel[s.pos]) {
+		s.pos++
 	}
+	v, _ := strconv.ParseUint(s.sel[start:s.pos], 16, 32) // at most 6 hex digits always fit
 
-	v, err := strconv.ParseUint(s.sel[start:i], 16, 21)
-	if err != nil {
-		return "", err
+	switch {
+	case s.pos+1 < s.selLen && s.sel[s.pos] == '\r' && s.sel[s.pos+1] == '\n':
+		s.pos += 2 // \r\n is a single whitespace https://drafts.csswg.org/css-syntax-3/#input-preprocessing
+	case s.pos < s.selLen && s.isWhitespace(s.sel[s.pos]):
+		s.pos++ // a single whitespace after the hex digits belongs to the escape
 	}
 
-	s.pos = i
+	if v == 0 || 0xD800 <= v && v <= 0xDFFF || v > unicode.MaxRune {
+		return string(utf8.RuneError), nil
+	}
 
 	return string(rune(v)), nil
 }
 
+// isValidEscape checks if the characters at pos start an escape, a backslash not followed by a new line
+// as defined in https://drafts.csswg.org/css-syntax-3/#starts-with-a-valid-escape
+func (s SelParser) isValidEscape(pos int) bool {
+	return pos < s.selLen && s.sel[pos] == '\\' && (pos+1 >= s.selLen || !s.isNewLine(s.sel[pos+1]))
+}
+
+// isIdentifierStart checks if the characters at pos start an identifier, a name character that is not a digit,
+// an escape or '-' followed by any of them or by other '-'
+// as defined in https://drafts.csswg.org/css-syntax-3/#would-start-an-identifier
+func (s SelParser) isIdentifierStart(pos int) bool {
+	if pos < s.selLen && s.sel[pos] == '-' {
+		pos++
+		if pos < s.selLen && s.sel[pos] == '-' {
+			return true
+		}
+	}
+	if pos >= s.selLen {
+		return false
+	}
+
+	char := s.sel[pos]
+	return s.isValidIdentifierChar(char) && char != '-' && !('0' <= char && char <= '9') || s.isValidEscape(pos)
+}
+
+// parseIdentifier parses the name and escaped characters of an identifier until any other character
+// as defined in https://drafts.csswg.org/css-syntax-3/#consume-name
+func (s *SelParser) parseIdentifier() (string, error) {
+	var ident strings.Builder
+	for s.pos < s.selLen {
+		char := s.sel[s.pos]
+
+		switch {
+		case s.isValidIdentifierChar(char):
+			ident.WriteByte(char) // keep the bytes of multi-byte characters
+			s.pos++
+		case s.isValidEscape(s.pos): // sel have an escaped element https://drafts.csswg.org/css-syntax-3/#escaping
+			c, err := s.parseEscape()
+			if err != nil {
+				return "", err
+			}
+			ident.WriteString(c)
+		default: // end of the identifier, the remaining input belongs to other selector
+			return ident.String(), nil
+		}
+	}
+
+	return ident.String(), nil
+}
+
+// parseString parses a quoted string (formats "val" and 'val') with its escaped characters
+// as defined in https://drafts.csswg.org/css-syntax-3/#consume-string-token
+func (s *SelParser) parseString() (string, error) {
+	if s.pos >= s.selLen || s.sel[s.pos] != '"' && s.sel[s.pos] != '\'' {
+		return "", s.newError("string (\"val\" or 'val')")
+	}
+	quote := s.sel[s.pos]
+	s.pos++
+
+	var str strings.Builder
+	for s.pos < s.selLen {
+		char := s.sel[s.pos]
+
+		switch {
+		case char == quote:
+			s.pos++
+			return str.String(), nil
+		case s.isNewLine(char):
+			return "", s.newError("end of string (" + string(quote) + ")")
+		case char == '\\' && s.pos+1 >= s.selLen: // an escape at the end of the input is ignored
+			s.pos++
+		case char == '\\' && s.sel[s.pos+1] == '\r' && s.pos+2 < s.selLen && s.sel[s.pos+2] == '\n':
+			s.pos += 3 // an escaped new line continues the string
+		case char == '\\' && s.isNewLine(s.sel[s.pos+1]):
+			s.pos += 2
+		case char == '\\':
+			c, err := s.parseEscape()
+			if err != nil {
+				return "", err
+			}
+			str.WriteString(c)
+		default:
+			str.WriteByte(char)
+			s.pos++
+		}
+	}
+
+	return "", s.newError("end of string (" + string(quote) + ")")
+}
+
+// serializeIdentifier escapes ident to be parsed back as a single identifier
+// as defined in https://drafts.csswg.org/cssom/#serialize-an-identifier
+func serializeIdentifier(ident string) string {
+	var str strings.Builder
+	for i, r := range ident {
+		switch {
+		case r == 0:
+			str.WriteRune(utf8.RuneError)
+		case r < 0x20 || r == 0x7f || i == 0 && '0' <= r && r <= '9' || i == 1 && '0' <= r && r <= '9' && ident[0] == '-':
+			fmt.Fprintf(&str, "\\%x ", r)
+		case i == 0 && r == '-' && len(ident) == 1:
+			str.WriteString("\\-")
+		case r >= 0x80 || r == '-' || r == '_' || '0' <= r && r <= '9' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z':
+			str.WriteRune(r)
+		default:
+			str.WriteByte('\\')
+			str.WriteRune(r)
+		}
+	}
+
+	return str.String()
+}
+
+// serializeString quotes str escaping the characters that can not appear inside a string
+// as defined in https://drafts.csswg.org/cssom/#serialize-a-string
+func serializeString(str string) string {
+	var quoted strings.Builder
+	quoted.WriteByte('"')
+	for _, r := range str {
+		switch {
+		case r == 0:
+			quoted.WriteRune(utf8.RuneError)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&quoted, "\\%x ", r)
+		case r == '"' || r == '\\':
+			quoted.WriteByte('\\')
+			quoted.WriteRune(r)
+		default:
+			quoted.WriteRune(r)
+		}
+	}
+	quoted.WriteByte('"')
+
+	return quoted.String()
+}
+
 // isValidTagNameChar checks if is valid character for id name
 // as defined in https://html.spec.whatwg.org/dev/syntax.html#syntax-tag-name
 func (s SelParser) isValidTagNameChar(char byte) bool {
@@ -64,6 +265,28 @@ func (s SelParser) isValidIdentifierChar(char byte) bool {
 	return s.isValidTagNameChar(char) || char == '_' || char == '-' || char > 127
 }
 
+// isWhitespace checks if is a whitespace character
+// as defined in https://drafts.csswg.org/css-syntax-3/#whitespace
+func (s SelParser) isWhitespace(char byte) bool {
+	return char == ' ' || char == '\t' || char == '\n' || char == '\r' || char == '\f'
+}
+
+// isNewLine checks if is a new line character
+// as defined in https://drafts.csswg.org/css-syntax-3/#newline
+func (s SelParser) isNewLine(char byte) bool {
+	return char == '\n' || char == '\r' || char == '\f'
+}
+
+// skipWhitespace advances over consecutive whitespace characters, returning if any was found
+func (s *SelParser) skipWhitespace() bool {
+	start := s.pos
+	for s.pos < s.selLen && s.isWhitespace(s.sel[s.pos]) {
+		s.pos++
+	}
+
+	return s.pos > start
+}
+
 // isHexChar checks if is a hexadecimal character
 // as defined in https://infra.spec.whatwg.org/#code-point
 func (s SelParser) isHexChar(char byte) bool {
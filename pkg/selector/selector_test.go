@@ -0,0 +1,63 @@
+package selector
+
+import (
+	"testing"
+)
+
+func TestSel_Specificity(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     [3]int
+	}{
+		{name: "universal selector", selector: "*", want: [3]int{0, 0, 0}},
+		{name: "tag selector", selector: "li", want: [3]int{0, 0, 1}},
+		{name: "id selector", selector: "#list", want: [3]int{1, 0, 0}},
+		{name: "class selector", selector: ".item", want: [3]int{0, 1, 0}},
+		{name: "attribute selector", selector: "[href]", want: [3]int{0, 1, 0}},
+		{name: "pseudo-class selector", selector: ":first-child", want: [3]int{0, 1, 0}},
+		{name: "compound selector", selector: `li.item[data-x="1"]#first`, want: [3]int{1, 2, 1}},
+		{name: "combinators", selector: "ul#list > li ~ li + li .item", want: [3]int{1, 1, 4}},
+		{name: "selector list uses the most specific", selector: "li, #list, .item", want: [3]int{1, 0, 0}},
+		{name: ":where is zero", selector: "li:where(#list, .item)", want: [3]int{0, 0, 1}},
+		{name: ":is uses the most specific argument", selector: "li:is(#list, .item)", want: [3]int{1, 0, 1}},
+		{name: ":not uses the most specific argument", selector: ":not(.a.b, li)", want: [3]int{0, 2, 0}},
+		{name: ":has uses the most specific argument", selector: "li:has(> a.b, img)", want: [3]int{0, 1, 2}},
+		{name: ":nth-child is a pseudo-class", selector: "li:nth-child(2n+1)", want: [3]int{0, 1, 1}},
+		{name: ":nth-child adds the 'of' selector", selector: ":nth-child(2n+1 of li.item, #a)", want: [3]int{1, 1, 0}},
+		{name: ":root is a pseudo-class", selector: ":root > li", want: [3]int{0, 1, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			got, err := Compile(tt.selector)
+			if err != nil {
+				t1.Errorf("Compile() error = %v", err)
+				return
+			}
+			if res := got.Specificity(); res != tt.want {
+				t1.Errorf("Specificity() = %v, want %v", res, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareSpecificity(t *testing.T) {
+	tests := []struct {
+		name string
+		a    [3]int
+		b    [3]int
+		want int
+	}{
+		{name: "equal specificity", a: [3]int{1, 2, 3}, b: [3]int{1, 2, 3}, want: 0},
+		{name: "id wins over any number of classes", a: [3]int{1, 0, 0}, b: [3]int{0, 20, 0}, want: 1},
+		{name: "class wins over any number of tags", a: [3]int{0, 1, 0}, b: [3]int{0, 1, 1}, want: -1},
+		{name: "tags decide on equal ids and classes", a: [3]int{0, 1, 2}, b: [3]int{0, 1, 1}, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			if got := CompareSpecificity(tt.a, tt.b); got != tt.want {
+				t1.Errorf("CompareSpecificity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,27 +1,48 @@
 package selector
 
 import (
-	"fmt"
 	"strings"
 
 	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
 )
 
 type TagSelector struct {
-	tag string
+	tag  string    // lower case name matched by html elements
+	name string    // name as written, foreign elements keep the case of their names like foreignObject in svg
+	atom atom.Atom // zero for unknown and custom elements
+	ns   namespaceComponent
 }
 
 func (t TagSelector) Match(n *html.Node) bool {
-	return n.Type == html.ElementNode && n.Data == t.tag
+	if n.Type != html.ElementNode || !t.ns.matches(n.Namespace) {
+		return false
+	}
+	if n.Namespace != "" { // only the names of html elements ignore case
+		return n.Data == t.name
+	}
+	if t.atom != 0 && n.DataAtom != 0 { // parsed nodes carry the atom of their name
+		return n.DataAtom == t.atom
+	}
+
+	return n.Data == t.tag
+}
+
+func (t TagSelector) Specificity() [3]int {
+	return [3]int{0, 0, 1}
+}
+
+func (t TagSelector) String() string {
+	return t.ns.String() + serializeIdentifier(t.name)
 }
 
 type TagParser struct {
-	SelParser
+	*SelParser
 }
 
 func NewTagParser(sel string) *TagParser {
 	return &TagParser{
-		SelParser{
+		&SelParser{
 			sel:    sel,
 			selLen: len(sel),
 		},
@@ -29,39 +50,24 @@ func NewTagParser(sel string) *TagParser {
 }
 
 func (t *TagParser) Parse() (Sel, error) {
-	if !(t.isValidTagNameChar(t.sel[t.pos]) || t.sel[t.pos] == '\\') {
-		return nil, fmt.Errorf("expected id selector (key), found '%c'", t.sel[t.pos])
+	ns, err := t.parseNamespace(true)
+	if err != nil {
+		return nil, err
+	}
+	if !t.isIdentifierStart(t.pos) {
+		return nil, t.newError("type selector (tag)")
 	}
 
-	tag := ""
-	for t.pos < t.selLen {
-		char := t.sel[t.pos]
-
-		switch {
-		case t.isValidTagNameChar(char): // get current "i" if is a valid name character
-			tag += string(char)
-			t.pos++
-			break
-		case char == '\\': // sel have an escaped element https://drafts.csswg.org/css-syntax-3/#escaping
-			c, err := t.parseEscape()
-			if err != nil {
-				return nil, err
-			}
-			tag += c
-			break
-		case char == '\r':
-			t.pos++
-			if t.sel[t.pos] == '\n' {
-				t.pos++ // for end of lines \r\n
-			}
-			break
-		case char == ' ' || char == '\n' || char == '\t':
-			t.pos++
-			break
-		}
+	tag, err := t.parseIdentifier()
+	if err != nil {
+		return nil, err
 	}
 
+	lower := strings.ToLower(tag)
 	return &TagSelector{
-		tag: strings.ToLower(tag),
+		tag:  lower,
+		name: tag,
+		atom: atom.Lookup([]byte(lower)),
+		ns:   ns,
 	}, nil
 }
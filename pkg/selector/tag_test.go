@@ -18,77 +18,102 @@ func TestTagParser_Parse(t1 *testing.T) {
 		{
 			name:     "parse a basic id",
 			selector: "section",
-			want:     &TagSelector{tag: "section"},
+			want:     &TagSelector{tag: "section", name: "section", atom: atom.Section},
 		},
 		{
 			name:     "parse a basic id with escaped letter",
-			selector: `s\ection`,
-			want:     &TagSelector{tag: "section"},
+			selector: `\section`,
+			want:     &TagSelector{tag: "section", name: "section", atom: atom.Section},
 		},
 		{
-			name:     "parse a basic id with \\n",
-			selector: "sect\nion",
-			want:     &TagSelector{tag: "section"},
+			name:     "parse a basic id ending at \\n",
+			selector: "section\nli",
+			want:     &TagSelector{tag: "section", name: "section", atom: atom.Section},
 		},
 		{
-			name:     "parse a basic id with \\r",
-			selector: "sect\rion",
-			want:     &TagSelector{tag: "section"},
+			name:     "parse a basic id ending at \\r",
+			selector: "section\rli",
+			want:     &TagSelector{tag: "section", name: "section", atom: atom.Section},
 		},
 		{
-			name:     "parse a basic id with \\t",
-			selector: "sect\tion",
-			want:     &TagSelector{tag: "section"},
+			name:     "parse a basic id ending at \\t",
+			selector: "section\tli",
+			want:     &TagSelector{tag: "section", name: "section", atom: atom.Section},
 		},
 		{
-			name:     "parse a basic id with \\r\\n",
-			selector: "sect\r\nion",
-			want:     &TagSelector{tag: "section"},
+			name:     "parse a basic id ending at \\r\\n",
+			selector: "section\r\nli",
+			want:     &TagSelector{tag: "section", name: "section", atom: atom.Section},
 		},
 		{
-			name:     "parse a basic id with whitespace",
-			selector: "sect ion",
-			want:     &TagSelector{tag: "section"},
+			name:     "parse a basic id ending at whitespace",
+			selector: "section li",
+			want:     &TagSelector{tag: "section", name: "section", atom: atom.Section},
 		},
 		{
 			name:     "parse id with escaped element with 6 digits '\\000073' (s)",
 			selector: `\000073ection`,
-			want:     &TagSelector{tag: "section"},
+			want:     &TagSelector{tag: "section", name: "section", atom: atom.Section},
 		},
 		{
 			name:     "parse id with multiple escaped element with 6 digits '\\000073' (s) and '\\000069' (i)",
 			selector: `\000073ect\000069on`,
-			want:     &TagSelector{tag: "section"},
+			want:     &TagSelector{tag: "section", name: "section", atom: atom.Section},
 		},
 		{
 			name:     "parse id with escaped element with 2 digits and whitespace '\\73' (s)",
 			selector: `\73 ection`,
-			want:     &TagSelector{tag: "section"},
+			want:     &TagSelector{tag: "section", name: "section", atom: atom.Section},
 		},
 		{
 			name:     "parse id with multiple escaped element with 2 digits and whitespace '\\73' (s) and '\\69' (i)",
 			selector: `\73 ection`,
-			want:     &TagSelector{tag: "section"},
+			want:     &TagSelector{tag: "section", name: "section", atom: atom.Section},
+		},
+		{
+			name:     "parse id with escaped hex letters '\\ec' (ì)",
+			selector: `s\ection`,
+			want:     &TagSelector{tag: "s\u00ection", name: "s\u00ection"},
 		},
 		{
-			name:     "parse id with escaped UNICODE element '\\U+000073' (s)",
-			selector: `\U+000073ection`,
-			want:     &TagSelector{tag: "section"},
+			name:     "parse id with '\\U+' escaping only the letter U",
+			selector: `\U+0073 ection`,
+			want:     &TagSelector{tag: "u", name: "U", atom: atom.U},
 		},
 		{
-			name:     "parse id with multiple escaped UNICODE element '\\U+000073' (s) and '\\U+000069' (i)",
-			selector: `\U+000073ect\U+000069on`,
-			want:     &TagSelector{tag: "section"},
+			name:     "parse id with escaped non-hex character '\\:'",
+			selector: `svg\:rect`,
+			want:     &TagSelector{tag: "svg:rect", name: "svg:rect"},
 		},
 		{
-			name:     "parse id with escaped UNICODE element with 4 digits and whitespace '\\U+0073' (s)",
-			selector: `\U+0073 ection`,
-			want:     &TagSelector{tag: "section"},
+			name:     "parse id with escaped zero as replacement character",
+			selector: `\0 section`,
+			want:     &TagSelector{tag: "\ufffdsection", name: "\ufffdsection"},
 		},
 		{
-			name:     "parse id with multiple escaped UNICODE element with 4 digits and whitespace '\\U+0073' (s) and '\\U+0069' (i)",
-			selector: `\U+0073 ect\U+0069 on`,
-			want:     &TagSelector{tag: "section"},
+			name:     "parse tag starting with '-'",
+			selector: "-my-element",
+			want:     &TagSelector{tag: "-my-element", name: "-my-element"},
+		},
+		{
+			name:     "parse tag starting with '--' and digit",
+			selector: "--1",
+			want:     &TagSelector{tag: "--1", name: "--1"},
+		},
+		{
+			name:     "parse tag starting with escaped digit",
+			selector: `\31 a`,
+			want:     &TagSelector{tag: "1a", name: "1a"},
+		},
+		{
+			name:     "not parse tag starting with digit",
+			selector: "1a",
+			want:     nil,
+		},
+		{
+			name:     "not parse tag starting with '-' and digit",
+			selector: "-1",
+			want:     nil,
 		},
 	}
 	for _, tt := range tests {
@@ -128,6 +153,18 @@ func TestTagParser_Match(t *testing.T) {
 			tag:  "li",
 			want: false,
 		},
+		{
+			name: "match 'my-element' selector for custom <my-element>",
+			html: "<my-element></my-element>",
+			tag:  "my-element",
+			want: true,
+		},
+		{
+			name: "not match 'my-element' selector for <main>",
+			html: "<main></main>",
+			tag:  "my-element",
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t1 *testing.T) {
@@ -147,3 +184,38 @@ func TestTagParser_Match(t *testing.T) {
 		})
 	}
 }
+
+func TestTagSelector_Match_WithoutAtom(t *testing.T) {
+	tests := []struct {
+		name string
+		node *html.Node
+		sel  Sel
+		want bool
+	}{
+		{
+			name: "match node built without atom",
+			node: &html.Node{Type: html.ElementNode, Data: "section"},
+			sel:  &TagSelector{tag: "section", name: "section", atom: atom.Section},
+			want: true,
+		},
+		{
+			name: "match selector built without atom",
+			node: &html.Node{Type: html.ElementNode, DataAtom: atom.Section, Data: "section"},
+			sel:  &TagSelector{tag: "section", name: "section"},
+			want: true,
+		},
+		{
+			name: "not match node with other atom",
+			node: &html.Node{Type: html.ElementNode, DataAtom: atom.Div, Data: "div"},
+			sel:  &TagSelector{tag: "section", name: "section", atom: atom.Section},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			if got := tt.sel.Match(tt.node); got != tt.want {
+				t1.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
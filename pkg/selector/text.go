@@ -0,0 +1,141 @@
+package selector
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+type TextSelector struct {
+	text string
+	own  bool
+}
+
+func (t TextSelector) Match(n *html.Node) bool {
+	return html.ElementNode == n.Type && strings.Contains(textContent(n, t.own), t.text)
+}
+
+func (t TextSelector) Specificity() [3]int {
+	return [3]int{0, 1, 0}
+}
+
+func (t TextSelector) String() string {
+	if t.own {
+		return ":containsOwn(" + serializeString(t.text) + ")"
+	}
+
+	return ":contains(" + serializeString(t.text) + ")"
+}
+
+type RegexpSelector struct {
+	re *regexp.Regexp
+}
+
+func (t RegexpSelector) Match(n *html.Node) bool {
+	return html.ElementNode == n.Type && t.re.MatchString(textContent(n, false))
+}
+
+func (t RegexpSelector) Specificity() [3]int {
+	return [3]int{0, 1, 0}
+}
+
+func (t RegexpSelector) String() string {
+	return ":matches(" + serializeString(t.re.String()) + ")"
+}
+
+// textContent returns the concatenated text of the descendant text nodes of n, or only of its children when own is true
+func textContent(n *html.Node, own bool) string {
+	var text strings.Builder
+	var collect func(n *html.Node)
+	collect = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			switch {
+			case html.TextNode == c.Type:
+				text.WriteString(c.Data)
+			case html.ElementNode == c.Type && !own:
+				collect(c)
+			}
+		}
+	}
+	collect(n)
+
+	return text.String()
+}
+
+// parseText parses the arguments of the :contains(), :containsOwn() and :matches() extensions,
+// a quoted string or the raw text until the closing parenthesis, :matches() also accepts /re/ and /re/i
+func (p PseudoParser) parseText(name string) (Sel, error) {
+	if name == "matches" && p.pos < p.selLen && p.sel[p.pos] == '/' {
+		return p.parseRegexp()
+	}
+
+	start := p.pos
+	var text string
+	if p.pos < p.selLen && (p.sel[p.pos] == '"' || p.sel[p.pos] == '\'') {
+		var err error
+		if text, err = p.parseString(); err != nil {
+			return nil, err
+		}
+	} else {
+		end := strings.IndexByte(p.sel[p.pos:], ')')
+		if end == -1 {
+			p.pos = p.selLen
+			return nil, p.newError("end of pseudo-class arguments ())")
+		}
+		text = strings.TrimRight(p.sel[p.pos:p.pos+end], " \t\n\r\f")
+		p.pos += end
+	}
+
+	if name == "matches" {
+		re, err := regexp.Compile(text)
+		if err != nil {
+			return nil, p.newCauseError(err, start, p.sel[start:p.pos], "regular expression")
+		}
+
+		return &RegexpSelector{
+			re: re,
+		}, nil
+	}
+
+	return &TextSelector{
+		text: text,
+		own:  name == "containsown",
+	}, nil
+}
+
+// parseRegexp parses a regular expression literal (formats '/re/' and '/re/i'), '\/' escapes the slash
+func (p PseudoParser) parseRegexp() (Sel, error) {
+	start := p.pos
+	p.pos++
+
+	var expr strings.Builder
+	for ; p.pos < p.selLen && p.sel[p.pos] != '/'; p.pos++ {
+		if p.sel[p.pos] == '\\' && p.pos+1 < p.selLen && p.sel[p.pos+1] == '/' {
+			p.pos++
+		} else if p.sel[p.pos] == '\\' && p.pos+1 < p.selLen {
+			expr.WriteByte(p.sel[p.pos])
+			p.pos++
+		}
+		expr.WriteByte(p.sel[p.pos])
+	}
+	if p.pos >= p.selLen {
+		return nil, p.newError("end of regular expression (/)")
+	}
+	p.pos++
+
+	flags := ""
+	if p.pos < p.selLen && p.sel[p.pos] == 'i' {
+		flags = "(?i)"
+		p.pos++
+	}
+
+	re, err := regexp.Compile(flags + expr.String())
+	if err != nil {
+		return nil, p.newCauseError(err, start, p.sel[start:p.pos], "regular expression")
+	}
+
+	return &RegexpSelector{
+		re: re,
+	}, nil
+}
@@ -0,0 +1,117 @@
+package selector
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestPseudoParser_ParseText(t *testing.T) {
+	tests := []struct {
+		name       string
+		selector   string
+		extensions bool
+		want       string
+		wantErr    bool
+	}{
+		{name: "parse :contains with quoted text", selector: `:contains("Hello world")`, extensions: true, want: "Hello world"},
+		{name: "parse :contains with unquoted text", selector: `:contains( Hello world )`, extensions: true, want: "Hello world"},
+		{name: "parse :containsOwn", selector: `:containsOwn('it\'s')`, extensions: true, want: "it's"},
+		{name: "parse :matches with quoted expression", selector: `:matches("^a+$")`, extensions: true, want: "^a+$"},
+		{name: "parse :matches with regexp literal", selector: `:matches(/a\/b\d/)`, extensions: true, want: `a/b\d`},
+		{name: "parse :matches with case-insensitive regexp literal", selector: `:matches(/ab/i)`, extensions: true, want: "(?i)ab"},
+		{name: "throw error for :contains without extensions", selector: `:contains("a")`, wantErr: true},
+		{name: "throw error for :matches with bad expression", selector: `:matches(/a(/)`, extensions: true, wantErr: true},
+		{name: "throw error for unclosed regexp literal", selector: `:matches(/ab)`, extensions: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			got, err := Compiler{Extensions: tt.extensions}.Compile(tt.selector)
+			if (err != nil) != tt.wantErr {
+				t1.Errorf("Compile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			res := ""
+			switch sel := got.(type) {
+			case *TextSelector:
+				res = sel.text
+			case *RegexpSelector:
+				res = sel.re.String()
+			}
+			if res != tt.want {
+				t1.Errorf("Compile() got = %v, want %v", res, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextSelector_Match(t *testing.T) {
+	doc := `<html><body>
+<ul id="list">
+	<li id="first">Price: <b id="price">10 EUR</b></li>
+	<li id="second">Name: <b id="name">Product</b></li>
+</ul>
+</body></html>`
+	tests := []struct {
+		name     string
+		selector string
+		want     []string
+	}{
+		{name: "match :contains in descendants", selector: `li:contains("10 EUR")`, want: []string{"first"}},
+		{name: "match :contains in every ancestor", selector: `:contains(Product)`, want: []string{"list", "second", "name"}},
+		{name: "match :containsOwn only in own text", selector: `:containsOwn("Name:")`, want: []string{"second"}},
+		{name: "not match :containsOwn in descendants", selector: `li:containsOwn("Product")`, want: nil},
+		{name: "match :matches", selector: `b:matches(/^\d+ EUR$/)`, want: []string{"price"}},
+		{name: "match :matches case-insensitive", selector: `b:matches(/^product$/i)`, want: []string{"name"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			root, _ := html.Parse(strings.NewReader(doc))
+			s, err := Compiler{Extensions: true}.Compile(tt.selector)
+			if err != nil {
+				t1.Errorf("Compile() error = %v", err)
+				return
+			}
+			if got := ids(QueryAll(root, s)); !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextSelector_String(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     string
+	}{
+		{name: "serialize :contains", selector: `:contains(Hello world)`, want: `:contains("Hello world")`},
+		{name: "serialize :containsOwn", selector: `:CONTAINSOWN('say "hi"')`, want: `:containsOwn("say \"hi\"")`},
+		{name: "serialize :matches", selector: `:matches(/a\/b\d/i)`, want: `:matches("(?i)a/b\\d")`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t1 *testing.T) {
+			c := Compiler{Extensions: true}
+			got, err := c.Compile(tt.selector)
+			if err != nil {
+				t1.Errorf("Compile() error = %v", err)
+				return
+			}
+			if res := got.String(); res != tt.want {
+				t1.Errorf("String() = %v, want %v", res, tt.want)
+			}
+
+			again, err := c.Compile(got.String())
+			if err != nil {
+				t1.Errorf("Compile() error = %v for serialized selector %s", err, got.String())
+				return
+			}
+			if again.String() != got.String() {
+				t1.Errorf("String() = %v, want %v after compiling it again", again.String(), got.String())
+			}
+		})
+	}
+}
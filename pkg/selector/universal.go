@@ -1,34 +1,49 @@
 package selector
 
 import (
-	"fmt"
-
 	"golang.org/x/net/html"
 )
 
-type UniversalSelector struct{}
+type UniversalSelector struct {
+	ns namespaceComponent
+}
+
+func (t UniversalSelector) Match(n *html.Node) bool {
+	return t.ns.matches(n.Namespace)
+}
 
-func (t UniversalSelector) Match(_ *html.Node) bool {
-	return true
+func (t UniversalSelector) Specificity() [3]int {
+	return [3]int{0, 0, 0}
+}
+
+func (t UniversalSelector) String() string {
+	return t.ns.String() + "*"
 }
 
 type UniversalParser struct {
-	SelParser
+	*SelParser
 }
 
 func NewUniversalParser(sel string) *UniversalParser {
 	return &UniversalParser{
-		SelParser{
+		&SelParser{
 			sel:    sel,
 			selLen: len(sel),
 		},
 	}
 }
 
-func (t UniversalParser) Parse() (Sel, error) {
-	if t.sel[t.pos] != '*' {
-		return nil, fmt.Errorf("expected universal selector (*), found '%c'", t.sel[t.pos])
+func (t *UniversalParser) Parse() (Sel, error) {
+	ns, err := t.parseNamespace(true)
+	if err != nil {
+		return nil, err
+	}
+	if t.pos >= t.selLen || t.sel[t.pos] != '*' {
+		return nil, t.newError("universal selector (*)")
 	}
+	t.pos++
 
-	return &UniversalSelector{}, nil
+	return &UniversalSelector{
+		ns: ns,
+	}, nil
 }